@@ -0,0 +1,333 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package analysis defines a static analyzer reporting container wiring errors.
+//
+// The analyzer checks signatures of functions passed to NewFactory and NewEntrypoint
+// against the rules enforced by the container at runtime, and reports dependencies
+// which are not provided by any factory passed to Run, Validate or New when all
+// the options of the call are statically known within the package.
+//
+// The analyzer is compatible with `go vet`:
+//
+//	go install github.com/NVIDIA/gontainer/v2/analysis/cmd/gontainervet@latest
+//	go vet -vettool=$(which gontainervet) ./...
+package analysis
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// gontainerPath is the import path of the container package.
+const gontainerPath = "github.com/NVIDIA/gontainer/v2"
+
+// Analyzer reports container wiring errors.
+var Analyzer = &analysis.Analyzer{
+	Name:     "gontainer",
+	Doc:      "report invalid factory signatures and unsatisfied dependencies of gontainer wiring",
+	Run:      run,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+}
+
+// run runs the analyzer on the package.
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Walk through all call expressions in the package.
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		call := node.(*ast.CallExpr)
+		switch getGontainerFunc(pass, call) {
+		case "NewFactory":
+			checkFactory(pass, call)
+		case "NewEntrypoint":
+			checkEntrypoint(pass, call)
+		case "Run", "Validate", "New":
+			checkDependencies(pass, call)
+		}
+	})
+
+	// Analysis completed.
+	return nil, nil
+}
+
+// checkFactory reports invalid signatures of functions passed to NewFactory.
+func checkFactory(pass *analysis.Pass, call *ast.CallExpr) {
+	// Validate function type.
+	signature, ok := getSignature(pass, call)
+	if !ok {
+		pass.Reportf(call.Args[0].Pos(), "invalid factory type: %s", pass.TypesInfo.TypeOf(call.Args[0]))
+		return
+	}
+
+	// Validate function output types.
+	results := signature.Results()
+	switch {
+	// Factory returns exactly one service.
+	case results.Len() == 1 && isServiceType(results.At(0).Type()):
+
+	// Factory returns a service and an error.
+	case results.Len() == 2 && isServiceType(results.At(0).Type()) && isErrorType(results.At(1).Type()):
+
+	// Factory returns a service and a close callback.
+	case results.Len() == 2 && isServiceType(results.At(0).Type()) && isCloseCallback(results.At(1).Type()):
+
+	// Factory returns a service, a close callback and an error.
+	case results.Len() == 3 && isServiceType(results.At(0).Type()) && isCloseCallback(results.At(1).Type()) && isErrorType(results.At(2).Type()):
+
+	// Factory signature is invalid.
+	default:
+		pass.Reportf(call.Args[0].Pos(), "invalid factory signature: %s", signature)
+	}
+}
+
+// checkEntrypoint reports invalid signatures of functions passed to NewEntrypoint.
+func checkEntrypoint(pass *analysis.Pass, call *ast.CallExpr) {
+	// Validate function type.
+	signature, ok := getSignature(pass, call)
+	if !ok {
+		pass.Reportf(call.Args[0].Pos(), "invalid entrypoint type: %s", pass.TypesInfo.TypeOf(call.Args[0]))
+		return
+	}
+
+	// Validate function output types.
+	results := signature.Results()
+	switch {
+	// Function returns nothing.
+	case results.Len() == 0:
+
+	// Function returns an error.
+	case results.Len() == 1 && isErrorType(results.At(0).Type()):
+
+	// Function signature is invalid.
+	default:
+		pass.Reportf(call.Args[0].Pos(), "invalid entrypoint signature: %s", signature)
+	}
+}
+
+// checkDependencies reports dependencies not provided by the statically known options.
+func checkDependencies(pass *analysis.Pass, call *ast.CallExpr) {
+	// Options passed as a spread slice are not statically known.
+	if call.Ellipsis.IsValid() {
+		return
+	}
+
+	// Built-in services are always provided.
+	provided := getBuiltinTypes(pass)
+
+	// Collect provided types and consumers of all options.
+	var consumers []*ast.CallExpr
+	if !collectOptions(pass, call.Args, &provided, &consumers) {
+		return
+	}
+
+	// Validate dependencies of all consumers.
+	for _, consumer := range consumers {
+		signature, ok := getSignature(pass, consumer)
+		if !ok {
+			continue
+		}
+		params := signature.Params()
+		for index := 0; index < params.Len(); index++ {
+			param := params.At(index)
+			if isSpecialType(param.Type()) || isTypeProvided(provided, param.Type()) {
+				continue
+			}
+
+			// Report at the parameter when it is declared in the package.
+			pos := consumer.Args[0].Pos()
+			if param.Pos().IsValid() && param.Pkg() == pass.Pkg {
+				pos = param.Pos()
+			}
+			pass.Reportf(pos, "dependency not provided: %s", param.Type())
+		}
+	}
+}
+
+// collectOptions collects provided types and consumers of the options,
+// returning false if any of the options is not statically known.
+func collectOptions(pass *analysis.Pass, args []ast.Expr, provided *[]types.Type, consumers *[]*ast.CallExpr) bool {
+	for _, arg := range args {
+		// Only direct calls of the container functions are statically known.
+		call, ok := astutil.Unparen(arg).(*ast.CallExpr)
+		if !ok || call.Ellipsis.IsValid() {
+			return false
+		}
+
+		// Typed Provide factories are checked by the compiler, but provide types the same way.
+		funcName := getGontainerFunc(pass, call)
+		if strings.HasPrefix(funcName, "Provide") {
+			funcName = "NewFactory"
+		}
+
+		switch funcName {
+		case "NewFactory":
+			signature, ok := getSignature(pass, call)
+			if !ok || signature.Results().Len() == 0 {
+				return false
+			}
+			*provided = append(*provided, signature.Results().At(0).Type())
+			*consumers = append(*consumers, call)
+		case "NewService":
+			serviceType, ok := getTypeArg(pass, call)
+			if !ok {
+				return false
+			}
+			*provided = append(*provided, serviceType)
+		case "NewEntrypoint":
+			*consumers = append(*consumers, call)
+		case "NewModule", "When":
+			if len(call.Args) < 1 || !collectOptions(pass, call.Args[1:], provided, consumers) {
+				return false
+			}
+		case "WhenEnv":
+			if len(call.Args) < 2 || !collectOptions(pass, call.Args[2:], provided, consumers) {
+				return false
+			}
+		case "Export", "WithActiveProfiles":
+			continue
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// getGontainerFunc returns the name of the called container package function, or empty string.
+func getGontainerFunc(pass *analysis.Pass, call *ast.CallExpr) string {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != gontainerPath {
+		return ""
+	}
+	if fn.Type().(*types.Signature).Recv() != nil {
+		return ""
+	}
+	return fn.Name()
+}
+
+// getSignature returns the signature of the function passed as the first argument.
+func getSignature(pass *analysis.Pass, call *ast.CallExpr) (*types.Signature, bool) {
+	if len(call.Args) == 0 {
+		return nil, false
+	}
+	argType := pass.TypesInfo.TypeOf(call.Args[0])
+	if argType == nil {
+		return nil, false
+	}
+	signature, ok := argType.Underlying().(*types.Signature)
+	return signature, ok
+}
+
+// getTypeArg returns the first type argument of the generic function call.
+func getTypeArg(pass *analysis.Pass, call *ast.CallExpr) (types.Type, bool) {
+	// Unwrap explicit instantiation and package selector.
+	fun := astutil.Unparen(call.Fun)
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun = index.X
+	}
+	if index, ok := fun.(*ast.IndexListExpr); ok {
+		fun = index.X
+	}
+	if selector, ok := fun.(*ast.SelectorExpr); ok {
+		fun = selector.Sel
+	}
+
+	// Lookup the function instance.
+	ident, ok := fun.(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	instance, ok := pass.TypesInfo.Instances[ident]
+	if !ok || instance.TypeArgs.Len() == 0 {
+		return nil, false
+	}
+	return instance.TypeArgs.At(0), true
+}
+
+// getBuiltinTypes returns types of the built-in container services.
+func getBuiltinTypes(pass *analysis.Pass) []types.Type {
+	var results []types.Type
+	for _, pkg := range pass.Pkg.Imports() {
+		if pkg.Path() != gontainerPath {
+			continue
+		}
+		for _, name := range []string{"Resolver", "Invoker", "Inspector", "Health"} {
+			if obj := pkg.Scope().Lookup(name); obj != nil {
+				results = append(results, types.NewPointer(obj.Type()))
+			}
+		}
+	}
+	return results
+}
+
+// isTypeProvided returns true when any of the provided types satisfies the dependency type.
+func isTypeProvided(provided []types.Type, depType types.Type) bool {
+	for _, providedType := range provided {
+		if types.Identical(providedType, depType) {
+			return true
+		}
+		if iface, ok := depType.Underlying().(*types.Interface); ok && types.Implements(providedType, iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSpecialType returns true for Optional, Multiple and Tagged dependency types.
+func isSpecialType(typ types.Type) bool {
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != gontainerPath {
+		return false
+	}
+	switch named.Obj().Name() {
+	case "Optional", "Multiple", "Tagged":
+		return true
+	}
+	return false
+}
+
+// isServiceType returns true when the type may be a factory service type.
+func isServiceType(typ types.Type) bool {
+	return !isEmptyInterface(typ) && !isErrorType(typ)
+}
+
+// isEmptyInterface returns true when the type is an `any` interface.
+func isEmptyInterface(typ types.Type) bool {
+	iface, ok := typ.Underlying().(*types.Interface)
+	return ok && iface.NumMethods() == 0
+}
+
+// isErrorType returns true when the type is an interface implementing error.
+func isErrorType(typ types.Type) bool {
+	errType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	iface, ok := typ.Underlying().(*types.Interface)
+	return ok && types.Implements(iface, errType)
+}
+
+// isCloseCallback returns true when the type is a `func() error` close callback.
+func isCloseCallback(typ types.Type) bool {
+	signature, ok := typ.(*types.Signature)
+	return ok && signature.Params().Len() == 0 && signature.Results().Len() == 1 &&
+		types.Identical(signature.Results().At(0).Type(), types.Universe.Lookup("error").Type())
+}
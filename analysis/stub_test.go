@@ -0,0 +1,94 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package analysis
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// TestStub tests declarations of the container package stub against the real package.
+func TestStub(t *testing.T) {
+	// Parse declarations of the real and the stub packages.
+	real := parseDeclarations(t, "..")
+	stub := parseDeclarations(t, filepath.Join(analysistest.TestData(), "src", gontainerPath))
+
+	// Validate all stub declarations match the real declarations.
+	for name, have := range stub {
+		want, ok := real[name]
+		if !ok {
+			t.Errorf("stub declares %s missing in the real package", name)
+			continue
+		}
+		if have != want {
+			t.Errorf("stub declaration %s differs from the real package:\n  %s\n  %s", name, have, want)
+		}
+	}
+}
+
+// parseDeclarations returns exported top-level functions and kinds of types declared in the directory.
+func parseDeclarations(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	// Parse package files without tests.
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := map[string]string{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Describe exported declarations.
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				// Functions are described by signatures.
+				if decl.Recv == nil && decl.Name.IsExported() {
+					var sb strings.Builder
+					_ = printer.Fprint(&sb, fset, decl.Type)
+					results[decl.Name.Name] = sb.String()
+				}
+			case *ast.GenDecl:
+				// Types are described by kinds of their definitions.
+				for _, spec := range decl.Specs {
+					if spec, ok := spec.(*ast.TypeSpec); ok && spec.Name.IsExported() {
+						results[spec.Name.Name] = reflect.TypeOf(spec.Type).String()
+					}
+				}
+			}
+		}
+	}
+	return results
+}
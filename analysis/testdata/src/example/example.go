@@ -0,0 +1,68 @@
+package example
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/gontainer/v2"
+)
+
+type Config struct{}
+
+type Database struct{}
+
+type Cache struct{}
+
+type Queue struct{}
+
+type Logger interface{ Log(string) }
+
+type logger struct{}
+
+func (l *logger) Log(string) {}
+
+func newDatabase(*Config) (*Database, func() error, error) { return nil, nil, nil }
+
+func signatures() {
+	gontainer.NewFactory(func() *Config { return nil })
+	gontainer.NewFactory(func() (*Config, error) { return nil, nil })
+	gontainer.NewFactory(func() (*Config, func() error) { return nil, nil })
+	gontainer.NewFactory(newDatabase)
+	gontainer.NewFactory(42)                                            // want `invalid factory type: int`
+	gontainer.NewFactory(func() {})                                     // want `invalid factory signature: func\(\)`
+	gontainer.NewFactory(func() any { return nil })                     // want `invalid factory signature: func\(\) any`
+	gontainer.NewFactory(func() error { return nil })                   // want `invalid factory signature: func\(\) error`
+	gontainer.NewFactory(func() (*Config, *Config) { return nil, nil }) // want `invalid factory signature: func\(\) \(\*example.Config, \*example.Config\)`
+
+	gontainer.NewEntrypoint(func() {})
+	gontainer.NewEntrypoint(func() error { return nil })
+	gontainer.NewEntrypoint("main")                  // want `invalid entrypoint type: string`
+	gontainer.NewEntrypoint(func() int { return 0 }) // want `invalid entrypoint signature: func\(\) int`
+}
+
+func dependencies() {
+	_ = gontainer.Run(
+		gontainer.NewService(&Config{}),
+		gontainer.NewService[Logger](&logger{}),
+		gontainer.NewFactory(newDatabase),
+		gontainer.Provide1(func(*Database) *Queue { return nil }),
+		gontainer.NewModule("cache",
+			gontainer.Export[*Cache](),
+			gontainer.NewFactory(func(*Database) *Cache { return nil }),
+		),
+		gontainer.NewEntrypoint(func(
+			*Database, *Cache, *Queue, Logger, fmt.Stringer, // want `dependency not provided: fmt.Stringer`
+			*gontainer.Resolver, *gontainer.Invoker, *gontainer.Inspector, *gontainer.Health,
+			gontainer.Optional[string], gontainer.Multiple[int], gontainer.Tagged[int, string],
+		) {
+		}),
+	)
+
+	_ = gontainer.Validate(
+		gontainer.WhenEnv("STORAGE", "memory", gontainer.NewService(&Database{})),
+		gontainer.NewEntrypoint(func(*Database, *Config) {}), // want `dependency not provided: \*example.Config`
+	)
+
+	options := []gontainer.Option{gontainer.NewService(&Config{})}
+	_ = gontainer.Run(options...)
+	_ = gontainer.Run(options[0], gontainer.NewEntrypoint(func(*Config) {}))
+}
@@ -0,0 +1,62 @@
+// Package gontainer is a stub of the container package for analyzer tests.
+package gontainer
+
+type Option interface{ apply() }
+
+type FactoryOption interface{ applyFactory() }
+
+type EntrypointOption interface{ applyEntrypoint() }
+
+type Factory struct{}
+
+func (f *Factory) apply() {}
+
+type Entrypoint struct{}
+
+func (e *Entrypoint) apply() {}
+
+type Module struct{}
+
+func (m *Module) apply() {}
+
+type Condition struct{}
+
+func (c *Condition) apply() {}
+
+type Resolver struct{}
+
+type Invoker struct{}
+
+type Inspector struct{}
+
+type Health struct{}
+
+type Container struct{}
+
+type Optional[T any] struct{ value T }
+
+type Multiple[T any] []T
+
+type Tagged[T any, A any] []T
+
+func Run(options ...Option) error { return nil }
+
+func Validate(options ...Option) error { return nil }
+
+func New(options ...Option) (*Container, error) { return nil, nil }
+
+func NewFactory(function any, opts ...FactoryOption) *Factory { return nil }
+
+func Provide1[A, T any](function func(A) T, opts ...FactoryOption) *Factory { return nil }
+
+func NewService[T any](service T, opts ...FactoryOption) *Factory { return nil }
+
+func NewEntrypoint(function any, opts ...EntrypointOption) *Entrypoint { return nil }
+
+func NewModule(name string, options ...Option) *Module { return nil }
+
+func When(predicate func() bool, options ...Option) *Condition { return nil }
+
+func WhenEnv(key, value string, options ...Option) *Condition { return nil }
+
+func Export[T any]() Option { return nil }
@@ -0,0 +1,130 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// autoCloseTimeout limits the automatic `Shutdown(context.Context) error` call.
+const autoCloseTimeout = 30 * time.Second
+
+// WithAutoClose returns an option that closes the factory service automatically
+// when the factory does not return a close callback.
+//
+// The service is closed with the first method it implements of:
+//   - io.Closer, i.e. `Close() error`;
+//   - `Close()`;
+//   - `Shutdown(context.Context) error`, called with a 30 seconds timeout.
+//
+// Automatic cleanup flows through the regular teardown order and error reporting.
+//
+// Example:
+//
+//	gontainer.NewFactory(func(cfg *Config) (*sql.DB, error) {
+//	    return sql.Open("postgres", cfg.DSN)
+//	}, gontainer.WithAutoClose())
+func WithAutoClose() autoCloseOpt {
+	return autoCloseOpt{}
+}
+
+// autoCloseOpt enables the automatic cleanup of the factory service.
+type autoCloseOpt struct{}
+
+// applyFactory applies the option to the factory settings.
+func (o autoCloseOpt) applyFactory(s *factorySettings) {
+	s.autoClose = true
+}
+
+// WithAutoCloseMode returns a container option that closes services automatically
+// as if every factory was registered with WithAutoClose.
+//
+// Services registered by NewService are constructed outside the container and
+// are not closed by this mode unless registered with WithAutoClose explicitly.
+//
+// Example:
+//
+//	gontainer.Run(gontainer.WithAutoCloseMode(), app.Options()...)
+func WithAutoCloseMode() Option {
+	return autoCloseModeOpt{}
+}
+
+// autoCloseModeOpt enables the container-wide automatic cleanup.
+type autoCloseModeOpt struct{}
+
+// apply applies the automatic cleanup mode option to the given registry.
+func (o autoCloseModeOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.autoClose = true
+	return nil
+}
+
+// applyAutoCloseMode marks all factories except built-in and external services
+// as auto-closed in the automatic cleanup mode.
+func (r *registry) applyAutoCloseMode() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.autoClose {
+		return
+	}
+	for _, fact := range r.factories {
+		if !fact.builtin && !fact.external {
+			fact.autoClose = true
+		}
+	}
+}
+
+// getAutoClose returns the automatic close function of the factory service.
+func (f *factory) getAutoClose() func() error {
+	// Automatic cleanup is disabled or the service is not spawned.
+	outValue := f.getOutValue()
+	if !f.autoClose || !outValue.IsValid() {
+		return func() error { return nil }
+	}
+
+	// Skip nil services.
+	if isNilValue(outValue) {
+		return func() error { return nil }
+	}
+
+	// Detect the close method of the service.
+	switch service := outValue.Interface().(type) {
+	case io.Closer:
+		return service.Close
+	case interface{ Close() }:
+		return func() error {
+			service.Close()
+			return nil
+		}
+	case interface{ Shutdown(context.Context) error }:
+		return func() error {
+			ctx, cancel := context.WithTimeout(context.Background(), autoCloseTimeout)
+			defer cancel()
+			return service.Shutdown(ctx)
+		}
+	}
+
+	// The service has no close method.
+	return func() error { return nil }
+}
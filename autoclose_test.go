@@ -0,0 +1,114 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// testCloser implements io.Closer.
+type testCloser struct{ events *[]string }
+
+func (c *testCloser) Close() error {
+	*c.events = append(*c.events, "closer")
+	return errors.New("closer boom")
+}
+
+// testVoidCloser implements a Close method without an error.
+type testVoidCloser struct{ events *[]string }
+
+func (c *testVoidCloser) Close() {
+	*c.events = append(*c.events, "void closer")
+}
+
+// testShutdowner implements a Shutdown method with a context.
+type testShutdowner struct{ events *[]string }
+
+func (c *testShutdowner) Shutdown(ctx context.Context) error {
+	*c.events = append(*c.events, "shutdowner")
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("shutdown without deadline")
+	}
+	return ctx.Err()
+}
+
+// TestAutoClose tests automatic cleanup of services.
+func TestAutoClose(t *testing.T) {
+	var events []string
+	err := Run(
+		NewFactory(func() *testCloser { return &testCloser{events: &events} }, WithAutoClose()),
+		NewFactory(func(*testCloser) *testVoidCloser { return &testVoidCloser{events: &events} }, WithAutoClose()),
+		NewFactory(func(*testVoidCloser) (*testShutdowner, error) {
+			return &testShutdowner{events: &events}, nil
+		}, WithAutoClose()),
+		NewEntrypoint(func(*testShutdowner) {}),
+	)
+	equal(t, events, []string{"shutdowner", "void closer", "closer"})
+	equal(t, normalizeSourceLines(err.Error()), ""+
+		"closer boom\n\n"+
+		"Source:\n"+
+		"  Factory for *gontainer.testCloser")
+}
+
+// TestAutoCloseCallback tests close callbacks take precedence over automatic cleanup.
+func TestAutoCloseCallback(t *testing.T) {
+	var events []string
+	equal(t, Run(
+		NewFactory(func() (*testCloser, func() error) {
+			return &testCloser{events: &events}, func() error {
+				events = append(events, "callback")
+				return nil
+			}
+		}, WithAutoClose()),
+		NewFactory(func() *testVoidCloser { return nil }, WithAutoClose()),
+		NewEntrypoint(func(*testCloser, *testVoidCloser) {}),
+	), nil)
+	equal(t, events, []string{"callback"})
+}
+
+// TestAutoCloseMode tests automatic cleanup of all services.
+func TestAutoCloseMode(t *testing.T) {
+	var events []string
+	equal(t, Run(
+		WithAutoCloseMode(),
+		NewFactory(func() *testVoidCloser { return &testVoidCloser{events: &events} }),
+		NewFactory(func() *testShutdowner { return &testShutdowner{events: &events} }),
+		NewEntrypoint(func(*testVoidCloser, *testShutdowner) {}),
+	), nil)
+	equal(t, events, []string{"shutdowner", "void closer"})
+
+	// External services are closed only with the factory option.
+	events = nil
+	equal(t, Run(
+		WithAutoCloseMode(),
+		NewService(&testVoidCloser{events: &events}),
+		NewService(&testShutdowner{events: &events}, WithAutoClose()),
+		NewEntrypoint(func(*testVoidCloser, *testShutdowner) {}),
+	), nil)
+	equal(t, events, []string{"shutdowner"})
+
+	// Services are not closed without the option.
+	events = nil
+	equal(t, Run(
+		NewService(&testVoidCloser{events: &events}),
+		NewEntrypoint(func(*testVoidCloser) {}),
+	), nil)
+	equal(t, len(events), 0)
+}
@@ -0,0 +1,152 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime"
+	"slices"
+)
+
+// When creates a new condition registering the provided options only when the predicate is true.
+//
+// The predicate is evaluated by Run before the registry is validated. Factories and entrypoints
+// of an unsatisfied condition are not registered, but are still reported by the Inspector
+// together with the conditions deciding on them.
+//
+// Example:
+//
+//	gontainer.When(config.UsePostgres,
+//	    gontainer.NewFactory(newPostgresStorage),
+//	)
+func When(predicate func() bool, options ...Option) *Condition {
+	// Prepare condition description.
+	var funcName string
+	if predicate != nil {
+		fullName := runtime.FuncForPC(reflect.ValueOf(predicate).Pointer()).Name()
+		_, funcName = splitFuncName(fullName)
+	}
+
+	// Prepare condition instance.
+	return &Condition{
+		name:      fmt.Sprintf("When[%s]", funcName),
+		source:    getCallerSource(1),
+		predicate: predicate,
+		options:   slices.Clone(options),
+	}
+}
+
+// WhenEnv creates a new condition registering the provided options only when
+// the environment variable is set to the value.
+//
+// Example:
+//
+//	gontainer.WhenEnv("STORAGE", "postgres",
+//	    gontainer.NewFactory(newPostgresStorage),
+//	)
+func WhenEnv(key, value string, options ...Option) *Condition {
+	return &Condition{
+		name:      fmt.Sprintf("WhenEnv[%s=%s]", key, value),
+		source:    getCallerSource(1),
+		predicate: func() bool { return os.Getenv(key) == value },
+		options:   slices.Clone(options),
+	}
+}
+
+// Condition is a container option that registers a group of options depending on a predicate.
+type Condition struct {
+	name      string
+	source    string
+	predicate func() bool
+	options   []Option
+}
+
+// Name returns the human-readable name of the condition.
+func (c *Condition) Name() string {
+	return c.name
+}
+
+// Source returns the source package path of the condition.
+func (c *Condition) Source() string {
+	return c.source
+}
+
+// apply applies the condition option to the given registry.
+func (c *Condition) apply(registry *registry) error {
+	// Validate the predicate.
+	if c.predicate == nil {
+		return fmt.Errorf("invalid predicate: %s", c.name)
+	}
+
+	// Enter the condition scope.
+	registry.enterCondition(c.name, c.predicate())
+	defer registry.leaveCondition()
+
+	// Register condition options in the registry.
+	for _, option := range c.options {
+		if err := option.apply(registry); err != nil {
+			return fmt.Errorf("failed to load %s: %w", c.name, err)
+		}
+	}
+
+	// Condition registered.
+	return nil
+}
+
+// ConditionInfo describes a condition enclosing a factory or entrypoint.
+type ConditionInfo struct {
+	// Name is the human-readable name of the condition.
+	Name string
+
+	// Satisfied reports whether the condition predicate was true.
+	Satisfied bool
+}
+
+// condition is the condition internal representation.
+type condition struct {
+	// Condition name.
+	name string
+
+	// Condition predicate result.
+	satisfied bool
+
+	// Enclosing condition or nil.
+	parent *condition
+}
+
+// isSatisfied returns true when the condition and all enclosing conditions are satisfied.
+func (c *condition) isSatisfied() bool {
+	for current := c; current != nil; current = current.parent {
+		if !current.satisfied {
+			return false
+		}
+	}
+	return true
+}
+
+// getInfos returns descriptions of the condition and enclosing conditions, outermost first.
+func (c *condition) getInfos() []ConditionInfo {
+	var infos []ConditionInfo
+	for current := c; current != nil; current = current.parent {
+		infos = append(infos, ConditionInfo{Name: current.name, Satisfied: current.satisfied})
+	}
+	slices.Reverse(infos)
+	return infos
+}
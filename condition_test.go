@@ -0,0 +1,105 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestConditionRegistration tests conditional registration of factories.
+func TestConditionRegistration(t *testing.T) {
+	t.Setenv("GONTAINER_TEST_STORAGE", "postgres")
+
+	type storage interface{ Name() string }
+
+	var resolved storage
+	var active, inactive []FactoryInfo
+
+	equal(t, Run(
+		WhenEnv("GONTAINER_TEST_STORAGE", "memory",
+			NewFactory(func() storage { return testStorage("memory") }),
+			NewEntrypoint(func() error { return errors.New("must not be invoked") }),
+		),
+		WhenEnv("GONTAINER_TEST_STORAGE", "postgres",
+			NewFactory(func() storage { return testStorage("postgres") }),
+			When(isTestConditionFalse,
+				NewService(123),
+			),
+		),
+		NewEntrypoint(func(s storage, inspector *Inspector) {
+			resolved = s
+			active = withoutBuiltins(inspector.Factories())
+			inactive = inspector.Inactive()
+		}),
+	), nil)
+
+	equal(t, resolved.Name(), "postgres")
+
+	equal(t, len(active), 1)
+	equal(t, active[0].Active, true)
+	equal(t, active[0].Conditions, []ConditionInfo{
+		{Name: "WhenEnv[GONTAINER_TEST_STORAGE=postgres]", Satisfied: true},
+	})
+
+	equal(t, len(inactive), 3)
+	equal(t, inactive[0].Name, "Factory[func() gontainer.storage]")
+	equal(t, inactive[0].Active, false)
+	equal(t, inactive[0].Conditions, []ConditionInfo{
+		{Name: "WhenEnv[GONTAINER_TEST_STORAGE=memory]", Satisfied: false},
+	})
+	equal(t, strings.HasPrefix(inactive[1].Name, "Entrypoint["), true)
+	equal(t, inactive[1].Active, false)
+	equal(t, inactive[2].Name, "Service[int]")
+	equal(t, inactive[2].Active, false)
+	equal(t, inactive[2].Conditions, []ConditionInfo{
+		{Name: "WhenEnv[GONTAINER_TEST_STORAGE=postgres]", Satisfied: true},
+		{Name: "When[isTestConditionFalse]", Satisfied: false},
+	})
+}
+
+// TestConditionModuleExports tests export declarations of unsatisfied conditions.
+func TestConditionModuleExports(t *testing.T) {
+	err := Run(
+		NewModule("db",
+			When(isTestConditionFalse, Export[int]()),
+			NewService("string"),
+		),
+		NewEntrypoint(func(string) {}),
+	)
+	equal(t, err, nil)
+}
+
+// TestConditionErrors tests errors of conditional registration.
+func TestConditionErrors(t *testing.T) {
+	equal(t, When(nil).Name(), "When[]")
+	equal(t, strings.Contains(When(nil).Source(), "condition_test.go:"), true)
+
+	err := Run(When(nil, NewService(1)))
+	equal(t, err.Error(), "invalid predicate: When[]")
+
+	err = Run(When(func() bool { return true }, NewFactory(42)))
+	equal(t, err.Error(), "failed to load When[TestConditionErrors.func1]: invalid type: int")
+}
+
+type testStorage string
+
+func (s testStorage) Name() string { return string(s) }
+
+func isTestConditionFalse() bool { return false }
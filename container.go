@@ -18,10 +18,14 @@
 package gontainer
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"runtime"
 	"slices"
+	"sync"
+	"time"
 )
 
 // Run runs a container with a set of configured factories.
@@ -31,8 +35,173 @@ import (
 // in reverse order. It returns when all entrypoints have returned and
 // teardown has completed.
 func Run(options ...Option) error {
+	// Register provided options in the container.
+	container, err := newContainer(options...)
+	if err != nil {
+		return err
+	}
+
+	// Validate all factories in the container.
+	if err := container.registry.validateRegistry(); err != nil {
+		return err
+	}
+
+	// Start all factories in the container.
+	if err := container.Start(); err != nil {
+		return err
+	}
+
+	// Close all factories in the container.
+	if err := container.Close(); err != nil {
+		return err
+	}
+
+	// Service container executed.
+	return nil
+}
+
+// Validate validates a container with a set of configured factories without running it.
+//
+// Validate registers the provided options and validates the registry the same way as Run,
+// reporting missing dependencies, duplicated types, circular dependencies, missing
+// entrypoints and unused factories with WithUnusedCheck, but never spawns factories
+// nor invokes entrypoints.
+//
+// This is useful for checking the dependency graph in CI without opening sockets or files.
+//
+// Example:
+//
+//	func TestWiring(t *testing.T) {
+//	    if err := gontainer.Validate(app.Options()...); err != nil {
+//	        t.Fatal(err)
+//	    }
+//	}
+func Validate(options ...Option) error {
+	// Register provided options in the container.
+	container, err := newContainer(options...)
+	if err != nil {
+		return err
+	}
+
+	// Validate all factories in the container.
+	return container.registry.validateRegistry()
+}
+
+// New creates a container with a set of configured factories without invoking entrypoints.
+//
+// New registers the provided options and validates the registry the same way as Run,
+// except that entrypoints are not required. Services are spawned lazily via the container
+// Resolver and Invoker, entrypoints are invoked by Start, and all spawned factories are
+// torn down in reverse order by Close.
+//
+// This is useful for tests and tooling built on top of the same factory definitions.
+func New(options ...Option) (*Container, error) {
+	// Register provided options in the container.
+	container, err := newContainer(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate all factories in the container.
+	if errs := container.registry.validateFactories(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	// Service container created.
+	return container, nil
+}
+
+// Container is a service container created by New.
+type Container struct {
+	registry  *registry
+	resolver  *Resolver
+	invoker   *Invoker
+	inspector *Inspector
+	health    *Health
+	mutex     sync.Mutex
+	started   bool
+	closed    bool
+	done      chan struct{}
+}
+
+// Resolver returns the service resolver of the container.
+func (c *Container) Resolver() *Resolver {
+	return c.resolver
+}
+
+// Invoker returns the function invoker of the container.
+func (c *Container) Invoker() *Invoker {
+	return c.invoker
+}
+
+// Inspector returns the registry inspector of the container.
+func (c *Container) Inspector() *Inspector {
+	return c.inspector
+}
+
+// Health returns the health aggregator of the container.
+func (c *Container) Health() *Health {
+	return c.health
+}
+
+// Start spawns eager factories, invokes all entrypoints of the container synchronously
+// and then reports factories never spawned to WithUnspawnedReport functions.
+//
+// Entrypoints are not invoked when any of the eager factories fails.
+// An error is returned when the container is already started or closed.
+func (c *Container) Start() error {
+	// Allow only one start before the container is closed.
+	c.mutex.Lock()
+	if c.started || c.closed {
+		c.mutex.Unlock()
+		return errors.New("container already started or closed")
+	}
+	c.started = true
+	c.done = make(chan struct{})
+	defer close(c.done)
+	c.mutex.Unlock()
+
+	// Start the container.
+	defer c.registry.reportUnspawnedFactories()
+	if err := c.registry.spawnEagerFactories(); err != nil {
+		return err
+	}
+	c.registry.setStarted()
+	return c.registry.invokeEntrypoints()
+}
+
+// Close closes all spawned factories of the container in the reverse order.
+// Subsequent calls do nothing and return nil.
+//
+// When the container is started concurrently, restarts of running entrypoints are
+// stopped and factories are closed only after Start returns, so services stay alive
+// while entrypoints use them. Close must not be called from entrypoints.
+func (c *Container) Close() error {
+	// Allow only one close of the container.
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return nil
+	}
+	c.closed = true
+	done := c.done
+	c.mutex.Unlock()
+
+	// Stop restarting entrypoints and wait for them to return.
+	c.registry.stopRestarts()
+	if done != nil {
+		<-done
+	}
+
+	// Close the container.
+	return c.registry.closeFactories()
+}
+
+// newContainer creates a container and registers built-in services and provided options.
+func newContainer(options ...Option) (*Container, error) {
 	// Prepare services registry instance.
 	registry := &registry{}
+	registry.stopped = make(chan struct{})
 
 	// Prepare service resolver instance.
 	resolver := &Resolver{registry: registry}
@@ -40,40 +209,67 @@ func Run(options ...Option) error {
 	// Prepare function invoker instance.
 	invoker := &Invoker{registry: registry}
 
+	// Prepare registry inspector instance.
+	inspector := &Inspector{registry: registry}
+
+	// Prepare health aggregator instance.
+	health := &Health{registry: registry}
+
 	// Register service resolver instance in the registry.
 	if err := NewService(resolver).apply(registry); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Register function invoker instance in the registry.
 	if err := NewService(invoker).apply(registry); err != nil {
-		return err
+		return nil, err
+	}
+
+	// Register registry inspector instance in the registry.
+	if err := NewService(inspector).apply(registry); err != nil {
+		return nil, err
+	}
+
+	// Register health aggregator instance in the registry.
+	if err := NewService(health).apply(registry); err != nil {
+		return nil, err
+	}
+
+	// Mark built-in services to skip them in reports.
+	for _, fact := range registry.factories {
+		fact.builtin = true
 	}
 
 	// Register provided factories in the registry.
 	for _, option := range options {
 		if err := option.apply(registry); err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	// Validate all factories in the container.
-	if err := registry.validateRegistry(); err != nil {
-		return err
-	}
+	// Deactivate factories of inactive profiles.
+	registry.activateProfiles()
 
-	// Start all factories in the container.
-	if err := registry.invokeEntrypoints(); err != nil {
-		return err
-	}
+	// Deactivate factories replaced by overrides.
+	registry.applyOverrides()
 
-	// Close all factories in the container.
-	if err := registry.closeFactories(); err != nil {
-		return err
-	}
+	// Mark all factories as eager in the eager mode.
+	registry.applyEagerMode()
 
-	// Service container executed.
-	return nil
+	// Mark all factories as auto-closed in the automatic cleanup mode.
+	registry.applyAutoCloseMode()
+
+	// Build the type index of registered factories.
+	registry.getIndex()
+
+	// Container prepared.
+	return &Container{
+		registry:  registry,
+		resolver:  resolver,
+		invoker:   invoker,
+		inspector: inspector,
+		health:    health,
+	}, nil
 }
 
 // Option is the interface for container options.
@@ -93,12 +289,20 @@ type Option interface {
 //	gontainer.NewFactory(func(db *Database) (*Handler, func() error) { ... })
 //	gontainer.NewFactory(func(db *Database) (*Handler, func() error, error) { ... })
 func NewFactory(function any, opts ...FactoryOption) *Factory {
+	return newFunctionFactory(function, getCallerSource(1), nil, opts)
+}
+
+// newFunctionFactory creates a new service factory from the function declared at the source.
+//
+// The function is invoked by the call function when specified, or via reflection otherwise.
+func newFunctionFactory(
+	function any, source string, callFn func(inValues []reflect.Value) []reflect.Value, opts []FactoryOption,
+) *Factory {
 	funcValue := reflect.ValueOf(function)
 	funcType := reflect.TypeOf(function)
 
 	// Prepare factory description.
 	name := fmt.Sprintf("Factory[%s]", funcValue.Type())
-	source := getCallerSource(1)
 
 	// Prepare factory settings.
 	settings := factorySettings{}
@@ -160,12 +364,26 @@ func NewFactory(function any, opts ...FactoryOption) *Factory {
 
 			// Load the factory internal representation.
 			state, err := newFactory(
-				kindFactory, name, source, funcValue,
+				kindFactory, name, source, settings.annotations, funcValue,
 				getOutType, getOutValue, getOutClose, getOutError,
 			)
 			if err != nil {
 				return fmt.Errorf("failed to load %s: %w", name, err)
 			}
+			state.callFn = callFn
+
+			// Apply factory settings.
+			settings.applyState(state)
+
+			// Private factories must be registered in a module.
+			if state.private && registry.module == nil {
+				return fmt.Errorf("failed to load %s: private factory outside of module", name)
+			}
+
+			// Health checks must accept the factory service.
+			if err := state.validateHealthCheck(); err != nil {
+				return fmt.Errorf("failed to load %s: %w", name, err)
+			}
 
 			// Register factory in the registry.
 			registry.registerFactory(state)
@@ -217,13 +435,27 @@ func NewService[T any](service T, opts ...FactoryOption) *Factory {
 
 			// Load the factory internal representation.
 			state, err := newFactory(
-				kindFactory, name, source, funcValue,
+				kindFactory, name, source, settings.annotations, funcValue,
 				getOutType, getOutValue, getOutClose, getOutError,
 			)
 			if err != nil {
 				return fmt.Errorf("failed to load %s: %w", name, err)
 			}
 
+			// Apply factory settings.
+			settings.applyState(state)
+			state.external = true
+
+			// Private factories must be registered in a module.
+			if state.private && registry.module == nil {
+				return fmt.Errorf("failed to load %s: private factory outside of module", name)
+			}
+
+			// Health checks must accept the factory service.
+			if err := state.validateHealthCheck(); err != nil {
+				return fmt.Errorf("failed to load %s: %w", name, err)
+			}
+
 			// Register factory in the registry.
 			registry.registerFactory(state)
 
@@ -270,6 +502,30 @@ func (f *Factory) apply(registry *registry) error {
 // factorySettings holds configuration options applied to a Factory or Service.
 type factorySettings struct {
 	annotations []any
+	order       int
+	before      []reflect.Type
+	after       []reflect.Type
+	private     bool
+	profiles    []string
+	override    bool
+	eager       bool
+	autoClose   bool
+	healthCheck func(ctx context.Context, service any) error
+	healthType  reflect.Type
+}
+
+// applyState applies the settings to the factory internal representation.
+func (s *factorySettings) applyState(state *factory) {
+	state.order = s.order
+	state.before = s.before
+	state.after = s.after
+	state.private = s.private
+	state.profiles = s.profiles
+	state.override = s.override
+	state.eager = s.eager
+	state.autoClose = s.autoClose
+	state.healthCheck = s.healthCheck
+	state.healthType = s.healthType
 }
 
 // appendAnnotation appends an annotation value.
@@ -277,6 +533,26 @@ func (s *factorySettings) appendAnnotation(value any) {
 	s.annotations = append(s.annotations, value)
 }
 
+// WithOverride returns an option that makes the factory replace all other factories
+// providing its output type, e.g. to substitute a service with a mock. Factories are
+// matched the same way resolution does, so an override of an interface type replaces
+// factories of all implementations of the interface.
+//
+// Example:
+//
+//	gontainer.NewService[Mailer](&fakeMailer{}, gontainer.WithOverride())
+func WithOverride() overrideOpt {
+	return overrideOpt{}
+}
+
+// overrideOpt marks the factory as an override.
+type overrideOpt struct{}
+
+// applyFactory applies the option to the factory settings.
+func (o overrideOpt) applyFactory(s *factorySettings) {
+	s.override = true
+}
+
 // NewEntrypoint creates a new factory which will be called by the container.
 //
 // Example:
@@ -337,13 +613,16 @@ func NewEntrypoint(function any, opts ...EntrypointOption) *Entrypoint {
 
 			// Load the factory internal representation.
 			state, err := newFactory(
-				kindEntrypoint, name, source, funcValue,
+				kindEntrypoint, name, source, settings.annotations, funcValue,
 				getOutType, getOutValue, getOutClose, getOutError,
 			)
 			if err != nil {
 				return fmt.Errorf("failed to load %s: %w", name, err)
 			}
 
+			// Apply entrypoint settings.
+			settings.applyState(state)
+
 			// Register entrypoint in the registry.
 			registry.registerEntrypoint(state)
 
@@ -389,7 +668,19 @@ func (e *Entrypoint) apply(registry *registry) error {
 
 // entrypointSettings holds configuration options applied to an Entrypoint.
 type entrypointSettings struct {
-	annotations []any
+	annotations  []any
+	profiles     []string
+	waitReady    bool
+	readyTimeout time.Duration
+	restart      RestartPolicy
+}
+
+// applyState applies the settings to the entrypoint internal representation.
+func (s *entrypointSettings) applyState(state *factory) {
+	state.profiles = s.profiles
+	state.waitReady = s.waitReady
+	state.readyTimeout = s.readyTimeout
+	state.restart = s.restart
 }
 
 // appendAnnotation appends an annotation value.
@@ -24,6 +24,7 @@ import (
 	"regexp"
 	"sync/atomic"
 	"testing"
+	"time"
 )
 
 // TestContainer tests service container.
@@ -199,3 +200,171 @@ func normalizeSourceLines(s string) string {
 	sourceLineRegex := regexp.MustCompile(`\n {4}at [^\n]+`)
 	return sourceLineRegex.ReplaceAllString(s, "")
 }
+
+// TestContainerNew tests creation of a container without running it.
+func TestContainerNew(t *testing.T) {
+	closed := atomic.Bool{}
+	invoked := atomic.Bool{}
+
+	container, err := New(
+		NewFactory(func() (string, func() error) {
+			return "string", func() error {
+				closed.Store(true)
+				return nil
+			}
+		}),
+		NewFactory(func(s string) int { return len(s) }),
+		NewEntrypoint(func(int) { invoked.Store(true) }),
+	)
+	equal(t, err, nil)
+
+	value, err := Resolve[int](container.Resolver())
+	equal(t, err, nil)
+	equal(t, value, 6)
+
+	result, err := Invoke1[string](container.Invoker(), func(s string) string { return s + "!" })
+	equal(t, err, nil)
+	equal(t, result, "string!")
+
+	equal(t, len(container.Inspector().Entrypoints()), 1)
+	equal(t, invoked.Load(), false)
+
+	equal(t, container.Start(), nil)
+	equal(t, invoked.Load(), true)
+
+	equal(t, closed.Load(), false)
+	equal(t, container.Close(), nil)
+	equal(t, closed.Load(), true)
+}
+
+// TestContainerStartClose tests repeated starts and closes of a container.
+func TestContainerStartClose(t *testing.T) {
+	invoked, closed := 0, 0
+	container, err := New(
+		NewFactory(func() (string, func() error) {
+			return "string", func() error {
+				closed++
+				return nil
+			}
+		}),
+		NewEntrypoint(func(string) { invoked++ }),
+	)
+	equal(t, err, nil)
+
+	equal(t, container.Start(), nil)
+	equal(t, container.Start().Error(), "container already started or closed")
+	equal(t, invoked, 1)
+
+	equal(t, container.Close(), nil)
+	equal(t, container.Close(), nil)
+	equal(t, closed, 1)
+	equal(t, container.Start().Error(), "container already started or closed")
+}
+
+// TestContainerNewErrors tests validation errors of a created container.
+func TestContainerNewErrors(t *testing.T) {
+	container, err := New()
+	equal(t, err, nil)
+	equal(t, container != nil, true)
+
+	container, err = New(NewFactory(func(bool) string { return "" }))
+	equal(t, errors.Is(err, ErrDependencyNotResolved), true)
+	equal(t, container, (*Container)(nil))
+
+	container, err = New(NewFactory(42))
+	equal(t, err.Error(), "invalid type: int")
+	equal(t, container, (*Container)(nil))
+}
+
+// TestFactoryOverride tests replacing factories with overrides.
+func TestFactoryOverride(t *testing.T) {
+	var resolved string
+	var inactive []FactoryInfo
+
+	equal(t, Run(
+		NewService("real"),
+		NewService("mock", WithOverride()),
+		NewFactory(func() int { return 1 }),
+		NewEntrypoint(func(s string, i int, inspector *Inspector) {
+			resolved = s
+			inactive = inspector.Inactive()
+		}),
+	), nil)
+
+	equal(t, resolved, "mock")
+	equal(t, len(inactive), 1)
+	equal(t, inactive[0].Name, "Service[string]")
+	equal(t, inactive[0].Active, false)
+
+	err := Run(
+		NewService("mock1", WithOverride()),
+		NewService("mock2", WithOverride()),
+		NewEntrypoint(func(string) {}),
+	)
+	equal(t, errors.Is(err, ErrFactoryTypeDuplicated), true)
+}
+
+// TestFactoryOverrideInterface tests overrides of interface types replacing implementations.
+func TestFactoryOverrideInterface(t *testing.T) {
+	var resolved fmt.Stringer
+	var inactive []FactoryInfo
+
+	equal(t, Run(
+		NewFactory(func() *testStringer { return &testStringer{} }),
+		NewService[fmt.Stringer](time.Second, WithOverride()),
+		NewEntrypoint(func(s fmt.Stringer, inspector *Inspector) {
+			resolved = s
+			inactive = inspector.Inactive()
+		}),
+	), nil)
+
+	equal(t, resolved, fmt.Stringer(time.Second))
+	equal(t, len(inactive), 1)
+	equal(t, inactive[0].Type, reflect.TypeOf(&testStringer{}))
+}
+
+// TestFactoryOverrideRequested tests errors of dependencies replaced by overrides.
+func TestFactoryOverrideRequested(t *testing.T) {
+	err := Run(
+		NewFactory(func() *testStringer { return &testStringer{} }),
+		NewService[fmt.Stringer](time.Second, WithOverride()),
+		NewEntrypoint(func(*testStringer) {}),
+	)
+	equal(t, errors.Is(err, ErrDependencyInactive), true)
+	equal(t, normalizeSourceLines(err.Error()), ""+
+		"dependency is inactive: *gontainer.testStringer replaced by override Service[fmt.Stringer]\n\n"+
+		"Traceback:\n"+
+		"  Entrypoint")
+}
+
+// TestValidate tests validation of a container without running it.
+func TestValidate(t *testing.T) {
+	spawned := atomic.Bool{}
+	invoked := atomic.Bool{}
+
+	equal(t, Validate(
+		NewFactory(func() string {
+			spawned.Store(true)
+			return "string"
+		}),
+		NewEntrypoint(func(string) { invoked.Store(true) }),
+	), nil)
+	equal(t, spawned.Load(), false)
+	equal(t, invoked.Load(), false)
+
+	err := Validate(
+		NewFactory(func(bool) (*testFmtLeaf, error) { return nil, nil }),
+		NewEntrypoint(func(*testFmtLeaf) {}),
+	)
+	equal(t, errors.Is(err, ErrDependencyNotResolved), true)
+	equal(t, normalizeSourceLines(err.Error()), ""+
+		"dependency not resolved: bool\n\n"+
+		"Traceback:\n"+
+		"  Factory for *gontainer.testFmtLeaf")
+
+	err = Validate(NewFactory(func() string { return "" }))
+	equal(t, errors.Is(err, ErrNoEntrypointsProvided), true)
+
+	err = Validate(NewFactory(42))
+	equal(t, err.Error(), "invalid type: int")
+}
@@ -0,0 +1,142 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"slices"
+)
+
+// findCycles returns cycles of mutually dependent factories, each with its full path
+// starting and ending with the same factory, where each factory depends on the next one.
+//
+// The dependency graph is walked depth first once, starting from factories in registration
+// order, and a cycle is reported for every back edge, i.e. a dependency on a factory being
+// visited. Every group of mutually dependent factories has at least one back edge, and
+// distinct back edges name distinct cycles through the group.
+func (r *registry) findCycles() [][]*factory {
+	// Prepare unique dependency edges of all factories.
+	edges := make(map[*factory][]*factory, len(r.factories))
+	for _, fact := range r.factories {
+		for _, inType := range fact.inTypes {
+			for _, dependency := range r.findDependencies(inType, fact.module) {
+				if !slices.Contains(edges[fact], dependency) {
+					edges[fact] = append(edges[fact], dependency)
+				}
+			}
+		}
+	}
+
+	// Prepare the depth first walk state.
+	var cycles [][]*factory
+	var stack []*factory
+	visited := make(map[*factory]bool, len(r.factories))
+	onStack := make(map[*factory]bool, len(r.factories))
+
+	// Visit the factory and its dependencies recursively.
+	var visit func(fact *factory)
+	visit = func(fact *factory) {
+		visited[fact] = true
+		onStack[fact] = true
+		stack = append(stack, fact)
+
+		// Walk through all dependencies of the factory.
+		for _, next := range edges[fact] {
+			// Report the cycle closed by the back edge.
+			if onStack[next] {
+				start := slices.Index(stack, next)
+				cycle := slices.Clone(stack[start:])
+				cycles = append(cycles, append(cycle, next))
+				continue
+			}
+			if !visited[next] {
+				visit(next)
+			}
+		}
+
+		// Leave the factory.
+		stack = stack[:len(stack)-1]
+		onStack[fact] = false
+	}
+
+	// Visit all factories in registration order.
+	for _, fact := range r.factories {
+		if !visited[fact] {
+			visit(fact)
+		}
+	}
+
+	// Return found cycles.
+	return cycles
+}
+
+// findComponents returns strongly connected components of the graph using Tarjan's algorithm.
+func findComponents(factories []*factory, edges map[*factory][]*factory) [][]*factory {
+	// Prepare Tarjan's algorithm state.
+	var components [][]*factory
+	var stack []*factory
+	indices := make(map[*factory]int, len(factories))
+	lowlinks := make(map[*factory]int, len(factories))
+	onStack := make(map[*factory]bool, len(factories))
+
+	// Visit the factory and its successors recursively.
+	var connect func(fact *factory)
+	connect = func(fact *factory) {
+		indices[fact] = len(indices)
+		lowlinks[fact] = indices[fact]
+		stack = append(stack, fact)
+		onStack[fact] = true
+
+		// Walk through all successors of the factory.
+		for _, next := range edges[fact] {
+			if _, visited := indices[next]; !visited {
+				connect(next)
+				lowlinks[fact] = min(lowlinks[fact], lowlinks[next])
+			} else if onStack[next] {
+				lowlinks[fact] = min(lowlinks[fact], indices[next])
+			}
+		}
+
+		// Is the factory a root of a component?
+		if lowlinks[fact] != indices[fact] {
+			return
+		}
+
+		// Pop the component from the stack.
+		var component []*factory
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == fact {
+				break
+			}
+		}
+		components = append(components, component)
+	}
+
+	// Visit all factories in the given order.
+	for _, fact := range factories {
+		if _, visited := indices[fact]; !visited {
+			connect(fact)
+		}
+	}
+
+	// Return found components.
+	return components
+}
@@ -0,0 +1,103 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestFindCycles tests reporting of circular dependencies with full paths.
+func TestFindCycles(t *testing.T) {
+	t.Run("IndependentCycles", func(t *testing.T) {
+		err := Validate(
+			NewFactory(func(int8) int { return 0 }),
+			NewFactory(func(int) int8 { return 0 }),
+			NewFactory(func(uint8) uint { return 0 }),
+			NewFactory(func(uint16) uint8 { return 0 }),
+			NewFactory(func(uint) uint16 { return 0 }),
+			NewEntrypoint(func(int, uint) {}),
+		)
+		equal(t, errors.Is(err, ErrCircularDependency), true)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"circular dependency\n\n"+
+			"Traceback:\n"+
+			"  Factory for int\n"+
+			"  Factory for int8\n"+
+			"  Factory for int\n\n"+
+			"circular dependency\n\n"+
+			"Traceback:\n"+
+			"  Factory for uint\n"+
+			"  Factory for uint16\n"+
+			"  Factory for uint8\n"+
+			"  Factory for uint")
+	})
+
+	t.Run("CyclesOfGroup", func(t *testing.T) {
+		err := Validate(
+			NewFactory(func(int8, int16) int { return 0 }),
+			NewFactory(func(int32) int8 { return 0 }),
+			NewFactory(func(int) int16 { return 0 }),
+			NewFactory(func(int) int32 { return 0 }),
+			NewEntrypoint(func(int) {}),
+		)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"circular dependency\n\n"+
+			"Traceback:\n"+
+			"  Factory for int\n"+
+			"  Factory for int32\n"+
+			"  Factory for int8\n"+
+			"  Factory for int\n\n"+
+			"circular dependency\n\n"+
+			"Traceback:\n"+
+			"  Factory for int\n"+
+			"  Factory for int16\n"+
+			"  Factory for int")
+	})
+
+	t.Run("SelfDependency", func(t *testing.T) {
+		err := Validate(
+			NewFactory(func(Multiple[fmt.Stringer]) *testStringer { return &testStringer{} }),
+			NewEntrypoint(func(*testStringer) {}),
+		)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"circular dependency\n\n"+
+			"Traceback:\n"+
+			"  Factory for *gontainer.testStringer\n"+
+			"  Factory for *gontainer.testStringer")
+	})
+
+	t.Run("Diamonds", func(t *testing.T) {
+		equal(t, Validate(newBenchmarkOptions(10, 10)...), nil)
+	})
+}
+
+// BenchmarkValidateDiamonds benchmarks validation of wide diamond-shaped graphs.
+func BenchmarkValidateDiamonds(b *testing.B) {
+	for _, size := range [][2]int{{5, 5}, {10, 10}, {20, 20}, {10, 50}} {
+		options := newBenchmarkOptions(size[0], size[1])
+		b.Run(fmt.Sprintf("Layers/%d/Width/%d", size[0], size[1]), func(b *testing.B) {
+			for index := 0; index < b.N; index++ {
+				if err := Validate(options...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+// WithEager returns an option that spawns the factory before entrypoints are invoked,
+// even if nothing depends on its service.
+//
+// This is useful for background services, e.g. a metrics exporter nobody injects,
+// whose errors would otherwise never surface because of the lazy spawning.
+//
+// Example:
+//
+//	gontainer.NewFactory(newMetricsExporter, gontainer.WithEager())
+func WithEager() eagerOpt {
+	return eagerOpt{}
+}
+
+// eagerOpt marks the factory as eager.
+type eagerOpt struct{}
+
+// applyFactory applies the option to the factory settings.
+func (o eagerOpt) applyFactory(s *factorySettings) {
+	s.eager = true
+}
+
+// WithEagerMode returns a container option that spawns all factories before
+// entrypoints are invoked, as if every factory was registered with WithEager.
+//
+// Example:
+//
+//	gontainer.Run(gontainer.WithEagerMode(), app.Options()...)
+func WithEagerMode() Option {
+	return eagerModeOpt{}
+}
+
+// eagerModeOpt enables the container-wide eager mode.
+type eagerModeOpt struct{}
+
+// apply applies the eager mode option to the given registry.
+func (o eagerModeOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.eager = true
+	return nil
+}
+
+// applyEagerMode marks all factories except built-in services as eager in the eager mode.
+func (r *registry) applyEagerMode() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.eager {
+		return
+	}
+	for _, fact := range r.factories {
+		fact.eager = !fact.builtin
+	}
+}
+
+// spawnEagerFactories spawns eager factories in registration order.
+//
+// Dependencies of eager factories are spawned first by the regular resolution,
+// so the factories are spawned in dependency order.
+func (r *registry) spawnEagerFactories() error {
+	// Prepare result errors accumulator.
+	var errs errorGroup
+
+	// Spawn all eager factories.
+	for _, fact := range r.factories {
+		if !fact.eager {
+			continue
+		}
+		if _, err := r.spawnFactories(nil, []*factory{fact}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Return nil if no errors found.
+	if len(errs) == 0 {
+		return nil
+	}
+
+	// Return collected errors.
+	return errs
+}
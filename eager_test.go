@@ -0,0 +1,106 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestEagerFactories tests spawning of eager factories before entrypoints.
+func TestEagerFactories(t *testing.T) {
+	var events []string
+	equal(t, Run(
+		NewFactory(func() *testFmtLeaf {
+			events = append(events, "leaf")
+			return &testFmtLeaf{}
+		}),
+		NewFactory(func(*testFmtLeaf) *testFmtMid {
+			events = append(events, "mid")
+			return &testFmtMid{}
+		}, WithEager()),
+		NewFactory(func() *testFmtRootA {
+			events = append(events, "lazy")
+			return &testFmtRootA{}
+		}),
+		NewEntrypoint(func(inspector *Inspector) {
+			events = append(events, "entrypoint")
+
+			factories := withoutBuiltins(inspector.Factories())
+			equal(t, factories[0].Eager, false)
+			equal(t, factories[1].Eager, true)
+			equal(t, factories[2].Eager, false)
+		}),
+	), nil)
+	equal(t, events, []string{"leaf", "mid", "entrypoint"})
+}
+
+// TestEagerMode tests spawning of all factories in the eager mode.
+func TestEagerMode(t *testing.T) {
+	var events []string
+	equal(t, Run(
+		WithEagerMode(),
+		NewFactory(func(*testFmtLeaf) *testFmtMid {
+			events = append(events, "mid")
+			return &testFmtMid{}
+		}),
+		NewFactory(func() *testFmtLeaf {
+			events = append(events, "leaf")
+			return &testFmtLeaf{}
+		}),
+		NewEntrypoint(func() {
+			events = append(events, "entrypoint")
+		}),
+	), nil)
+	equal(t, events, []string{"leaf", "mid", "entrypoint"})
+}
+
+// TestEagerErrors tests errors of eager factories.
+func TestEagerErrors(t *testing.T) {
+	invoked := false
+	err := Run(
+		NewFactory(func() (*testFmtLeaf, error) {
+			return nil, errors.New("leaf boom")
+		}),
+		NewFactory(func(*testFmtLeaf) *testFmtMid { return &testFmtMid{} }, WithEager()),
+		NewFactory(func() (*testFmtRootA, error) {
+			return nil, errors.New("root boom")
+		}, WithEager()),
+		NewEntrypoint(func() { invoked = true }),
+	)
+	equal(t, invoked, false)
+	equal(t, errors.Is(err, ErrFactoryReturnedError), true)
+	equal(t, normalizeSourceLines(err.Error()), ""+
+		"leaf boom\n\n"+
+		"Traceback:\n"+
+		"  Factory for *gontainer.testFmtLeaf\n"+
+		"  Factory for *gontainer.testFmtMid\n\n"+
+		"root boom\n\n"+
+		"Traceback:\n"+
+		"  Factory for *gontainer.testFmtRootA")
+}
+
+// TestEagerUnused tests eager factories are not reported as unused.
+func TestEagerUnused(t *testing.T) {
+	equal(t, Validate(
+		WithUnusedCheck(),
+		NewService(&testFmtLeaf{}),
+		NewFactory(func(*testFmtLeaf) *testFmtMid { return &testFmtMid{} }, WithEager()),
+		NewEntrypoint(func() {}),
+	), nil)
+}
@@ -39,13 +39,32 @@ var ErrEntrypointReturnedError = errors.New("entrypoint returned error")
 // ErrDependencyNotResolved declares service not resolved error.
 var ErrDependencyNotResolved = errors.New("dependency not resolved")
 
+// ErrDependencyPrivate declares service is private to another module error.
+var ErrDependencyPrivate = errors.New("dependency is private")
+
+// ErrDependencyInactive declares service is provided only by an inactive factory error.
+var ErrDependencyInactive = errors.New("dependency is inactive")
+
 // ErrCircularDependency declares a circular dependency error.
 var ErrCircularDependency = errors.New("circular dependency")
 
+// ErrContradictoryOrder declares a contradictory order constraints error.
+var ErrContradictoryOrder = errors.New("contradictory order")
+
+// ErrServiceNotReady declares a service not ready error.
+var ErrServiceNotReady = errors.New("service not ready")
+
+// ErrFactoryUnused declares a factory not reachable from any entrypoint error.
+var ErrFactoryUnused = errors.New("factory unused")
+
 // formatFactoryFrame renders a single factory or entrypoint as one traceback frame.
 func formatFactoryFrame(f *factory) string {
 	var sb strings.Builder
 	sb.WriteString("\n  ")
+	if f.module != nil {
+		sb.WriteString(f.module.path)
+		sb.WriteString(": ")
+	}
 	switch f.kind {
 	case kindEntrypoint:
 		sb.WriteString("Entrypoint")
@@ -69,18 +88,78 @@ func newDependencyNotResolvedError(requester *factory, missing reflect.Type) err
 	return fmt.Errorf("%w: %s%s", ErrDependencyNotResolved, missing, tail)
 }
 
+// newDependencyPrivateError reports that missing is only provided by a private service of another module.
+func newDependencyPrivateError(requester *factory, missing reflect.Type, provider *factory) error {
+	tail := "\n\nTraceback:"
+	if requester != nil {
+		tail += formatFactoryFrame(requester)
+	}
+	return fmt.Errorf("%w: %s in module %s%s%.0w", ErrDependencyPrivate, missing, provider.module.path, tail, ErrDependencyNotResolved)
+}
+
+// newDependencyInactiveError reports that missing is only provided by a factory skipped
+// by a condition or profile, or replaced by an override.
+func newDependencyInactiveError(requester *factory, missing reflect.Type, provider *factory) error {
+	tail := "\n\nTraceback:"
+	if requester != nil {
+		tail += formatFactoryFrame(requester)
+	}
+	var reason string
+	switch provider.inactiveReason {
+	case inactiveByOverride:
+		reason = "replaced by override " + provider.overriddenBy.name
+	case inactiveByProfile:
+		reason = "in profile " + strings.Join(provider.profiles, ", ")
+	default:
+		for _, info := range provider.condition.getInfos() {
+			if !info.Satisfied {
+				reason = "by condition " + info.Name
+				break
+			}
+		}
+	}
+	return fmt.Errorf("%w: %s %s%s%.0w", ErrDependencyInactive, missing, reason, tail, ErrDependencyNotResolved)
+}
+
 // newFactoryTypeDuplicatedError reports that more than one factory produces the same output type.
 func newFactoryTypeDuplicatedError(f *factory) error {
 	return fmt.Errorf("%w: %s\n\nTraceback:%s", ErrFactoryTypeDuplicated, f.getOutType(), formatFactoryFrame(f))
 }
 
-// newCircularDependencyError reports a cycle in the dependency graph starting at f.
-func newCircularDependencyError(f *factory) error {
-	return fmt.Errorf("%w\n\nTraceback:%s", ErrCircularDependency, formatFactoryFrame(f))
+// newCircularDependencyError reports a cycle in the dependency graph with its full path, innermost frame first.
+func newCircularDependencyError(cycle []*factory) error {
+	var sb strings.Builder
+	for index := len(cycle) - 1; index >= 0; index-- {
+		sb.WriteString(formatFactoryFrame(cycle[index]))
+	}
+	return fmt.Errorf("%w\n\nTraceback:%s", ErrCircularDependency, sb.String())
+}
+
+// newContradictoryOrderError reports a cycle in the before/after order constraints starting at f.
+func newContradictoryOrderError(f *factory) error {
+	return fmt.Errorf("%w\n\nTraceback:%s", ErrContradictoryOrder, formatFactoryFrame(f))
+}
+
+// newFactoryUnusedError reports that the service of f is not reachable from any entrypoint.
+func newFactoryUnusedError(f *factory) error {
+	return fmt.Errorf("%w: %s\n\nTraceback:%s", ErrFactoryUnused, f.getOutType(), formatFactoryFrame(f))
+}
+
+// newServiceNotReadyError reports that the service of f did not become ready and opens a Traceback section.
+func newServiceNotReadyError(f *factory, err error) error {
+	return fmt.Errorf("%w: %s: %w\n\nTraceback:%s", ErrServiceNotReady, f.getOutType(), err, formatFactoryFrame(f))
 }
 
 // newFactoryResolveFailedError appends f as an outer frame to an already-rendered resolve error.
 func newFactoryResolveFailedError(f *factory, err error) error {
+	// Append the frame to each error of dependencies resolved in parallel.
+	if group, ok := err.(errorGroup); ok {
+		results := make(errorGroup, 0, len(group))
+		for _, child := range group {
+			results = append(results, newFactoryResolveFailedError(f, child))
+		}
+		return results
+	}
 	return fmt.Errorf("%w%s", err, formatFactoryFrame(f))
 }
 
@@ -166,7 +166,7 @@ func TestErrorFormatDependencyNotResolvedTail(t *testing.T) {
 	equal(t, errors.Is(err, ErrDependencyNotResolved), true)
 }
 
-// TestErrorFormatCircularDependencyTail checks that each factory in the cycle reports its own "circular dependency" block.
+// TestErrorFormatCircularDependencyTail checks that the cycle reports its full path in a single "circular dependency" block.
 func TestErrorFormatCircularDependencyTail(t *testing.T) {
 	err := Run(
 		NewFactory(func(*testFmtMid) *testFmtRootA { return &testFmtRootA{} }),
@@ -180,17 +180,14 @@ func TestErrorFormatCircularDependencyTail(t *testing.T) {
 	unwrap, ok := err.(interface{ Unwrap() []error })
 	equal(t, ok, true)
 	errs := unwrap.Unwrap()
-	equal(t, len(errs), 2)
+	equal(t, len(errs), 1)
 	equal(t, normalizeSourceLines(errs[0].Error()), ""+
 		"circular dependency"+
 		"\n"+
 		"\nTraceback:"+
+		"\n  Factory for *gontainer.testFmtRootA"+
+		"\n  Factory for *gontainer.testFmtMid"+
 		"\n  Factory for *gontainer.testFmtRootA")
-	equal(t, normalizeSourceLines(errs[1].Error()), ""+
-		"circular dependency"+
-		"\n"+
-		"\nTraceback:"+
-		"\n  Factory for *gontainer.testFmtMid")
 }
 
 // TestErrorFormatMultipleTopLevelChains checks that two independent entrypoint failures are separated by a single blank line.
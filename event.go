@@ -0,0 +1,104 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"time"
+)
+
+// EventKind is the kind of a container event.
+type EventKind string
+
+const (
+	// EventFactorySpawned is emitted when a factory function returns, with or without an error.
+	EventFactorySpawned EventKind = "factory_spawned"
+
+	// EventFactoryClosed is emitted when a close callback of a spawned factory returns.
+	EventFactoryClosed EventKind = "factory_closed"
+
+	// EventEntrypointFailed is emitted when an entrypoint returns an error.
+	EventEntrypointFailed EventKind = "entrypoint_failed"
+
+	// EventEntrypointRestarting is emitted before an entrypoint is restarted by its restart policy.
+	EventEntrypointRestarting EventKind = "entrypoint_restarting"
+)
+
+// Event describes a container event.
+type Event struct {
+	// Kind is the kind of the event.
+	Kind EventKind
+
+	// Factory is the description of the factory or entrypoint of the event.
+	Factory FactoryInfo
+
+	// Err is the error of the event, if any.
+	Err error
+
+	// Attempt is the invocation attempt of the entrypoint, starting with 1.
+	Attempt int
+
+	// Delay is the backoff delay before the entrypoint restart.
+	Delay time.Duration
+
+	// Duration is the duration of the factory function or the close callback call.
+	Duration time.Duration
+}
+
+// WithEventHandler returns a container option that registers a handler of container events.
+//
+// Handlers are called synchronously in registration order from the goroutine emitting
+// the event, so they must not block.
+//
+// Example:
+//
+//	gontainer.WithEventHandler(func(event gontainer.Event) {
+//	    log.Printf("%s: %s: %v", event.Kind, event.Factory.Name, event.Err)
+//	})
+func WithEventHandler(handler func(event Event)) Option {
+	return eventHandlerOpt{handler: handler}
+}
+
+// eventHandlerOpt registers the event handler.
+type eventHandlerOpt struct {
+	handler func(event Event)
+}
+
+// apply applies the event handler option to the given registry.
+func (o eventHandlerOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.eventHandlers = append(registry.eventHandlers, o.handler)
+	return nil
+}
+
+// emitEvent calls all registered event handlers with the event of the factory.
+func (r *registry) emitEvent(fact *factory, event Event) {
+	// Skip describing the factory without handlers.
+	if len(r.eventHandlers) == 0 {
+		return
+	}
+
+	// Call all handlers.
+	event.Factory = newFactoryInfo(fact)
+	for _, handler := range r.eventHandlers {
+		handler(event)
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestEventHandler tests events of factories spawned and closed.
+func TestEventHandler(t *testing.T) {
+	var events []Event
+	err := Run(
+		WithEventHandler(func(event Event) { events = append(events, event) }),
+		NewFactory(func() (*testFmtMid, func() error) {
+			return &testFmtMid{}, func() error { return errors.New("close failed") }
+		}),
+		NewEntrypoint(func(*testFmtMid) {}),
+	)
+	equal(t, errors.Is(err, ErrFactoryReturnedError), false)
+	equal(t, len(events), 2)
+	equal(t, events[0].Kind, EventFactorySpawned)
+	equal(t, events[0].Factory.Type, reflect.TypeOf(&testFmtMid{}))
+	equal(t, events[0].Err, nil)
+	equal(t, events[1].Kind, EventFactoryClosed)
+	equal(t, events[1].Err.Error(), "close failed")
+}
+
+// TestEventHandlerFactoryError tests events of factories returning errors.
+func TestEventHandlerFactoryError(t *testing.T) {
+	var events []Event
+	err := Run(
+		WithEventHandler(func(event Event) { events = append(events, event) }),
+		NewFactory(func() (*testFmtMid, error) { return nil, errors.New("failed") }),
+		NewEntrypoint(func(*testFmtMid) {}),
+	)
+	equal(t, errors.Is(err, ErrFactoryReturnedError), true)
+	equal(t, len(events) > 0, true)
+	equal(t, events[0].Kind, EventFactorySpawned)
+	equal(t, events[0].Err.Error(), "failed")
+}
@@ -43,23 +43,29 @@ func main() {
 
 	// Execute service container.
 	err := gontainer.Run(
-		// Enable config service.
-		config.WithConfig(),
+		// Enable infrastructure services module.
+		gontainer.NewModule("infra",
+			// Enable config service.
+			config.WithConfig(),
 
-		// Enable logger service.
-		logging.WithSlogLogger(),
+			// Enable logger service.
+			logging.WithSlogLogger(),
 
-		// Enable HTTP server factory.
-		httpsvr.WithHTTPServer(),
+			// Enable HTTP server factory.
+			httpsvr.WithHTTPServer(),
+		),
 
-		// Enable application endpoints factory.
-		app.WithAppEndpoints(),
+		// Enable application module.
+		gontainer.NewModule("app",
+			// Enable application endpoints factory.
+			app.WithAppEndpoints(),
 
-		// Enable health check endpoints factory.
-		app.WithHealthEndpoints(),
+			// Enable health check endpoints factory.
+			app.WithHealthEndpoints(),
 
-		// Enable application entrypoint factory.
-		app.WithAppEntryPoint(terminate),
+			// Enable application entrypoint factory.
+			app.WithAppEntryPoint(terminate),
+		),
 	)
 
 	// Check if service container run failed.
@@ -18,9 +18,12 @@
 package gontainer
 
 import (
+	"context"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 )
 
 // splitFuncName splits specified func name to package and a name.
@@ -55,7 +58,7 @@ func splitFuncName(funcFullName string) (string, string) {
 
 // newFactory loads factory function to the internal representation.
 func newFactory(
-	kind factoryKind, name, source string, funcValue reflect.Value,
+	kind factoryKind, name, source string, annotations []any, funcValue reflect.Value,
 	getOutType getOutTypeFn, getOutValue getOutValueFn,
 	getOutClose getOutCloseFn, getOutError getOutErrorFn,
 ) (*factory, error) {
@@ -76,13 +79,14 @@ func newFactory(
 
 	// Prepare registry factory instance.
 	return &factory{
-		kind:      kind,
-		name:      name,
-		source:    source,
-		funcType:  funcType,
-		funcValue: funcValue,
-		inTypes:   inTypes,
-		outTypes:  outTypes,
+		kind:        kind,
+		name:        name,
+		source:      source,
+		annotations: annotations,
+		funcType:    funcType,
+		funcValue:   funcValue,
+		inTypes:     inTypes,
+		outTypes:    outTypes,
 
 		// Signature-dependent.
 		getOutTypeFn:  getOutType,
@@ -103,6 +107,23 @@ const (
 	kindEntrypoint
 )
 
+// inactiveReason defines why a factory is not registered.
+type inactiveReason int
+
+const (
+	// inactiveNone is the reason of active factories.
+	inactiveNone inactiveReason = iota
+
+	// inactiveByCondition is a factory registered within an unsatisfied condition.
+	inactiveByCondition
+
+	// inactiveByProfile is a factory of inactive profiles.
+	inactiveByProfile
+
+	// inactiveByOverride is a factory replaced by an overriding factory.
+	inactiveByOverride
+)
+
 // factory is the factory internal representation.
 type factory struct {
 	// Factory kind.
@@ -114,6 +135,78 @@ type factory struct {
 	// Factory func source.
 	source string
 
+	// Factory annotations.
+	annotations []any
+
+	// Factory module or nil.
+	module *module
+
+	// Factory is private to its module.
+	private bool
+
+	// Factory condition or nil.
+	condition *condition
+
+	// Factory profiles.
+	profiles []string
+
+	// Factory replaces factories of the same output type.
+	override bool
+
+	// Factory is registered by conditions, profiles and overrides.
+	active bool
+
+	// Reason why the factory is not registered.
+	inactiveReason inactiveReason
+
+	// Overriding factory replacing the factory, or nil.
+	overriddenBy *factory
+
+	// Factory is a built-in container service.
+	builtin bool
+
+	// Factory service is constructed outside the container by NewService.
+	external bool
+
+	// Factory is spawned before entrypoints.
+	eager bool
+
+	// Factory service is closed automatically without a close callback.
+	autoClose bool
+
+	// Factory service health check or nil.
+	healthCheck func(ctx context.Context, service any) error
+
+	// Factory service type accepted by the health check.
+	healthType reflect.Type
+
+	// Entrypoint waits for readiness of its dependencies.
+	waitReady bool
+
+	// Entrypoint readiness timeout or zero.
+	readyTimeout time.Duration
+
+	// Entrypoint restart policy.
+	restart RestartPolicy
+
+	// Duration of the last factory function call.
+	callDuration time.Duration
+
+	// Trace context mutex.
+	traceCtxMu sync.RWMutex
+
+	// Context of the last traced invocation, or nil.
+	traceCtx context.Context
+
+	// Factory order value.
+	order int
+
+	// Factory precedes services of these types.
+	before []reflect.Type
+
+	// Factory follows services of these types.
+	after []reflect.Type
+
 	// Factory spawn mutex.
 	spawnMu sync.Mutex
 
@@ -123,12 +216,21 @@ type factory struct {
 	// Factory is spawned.
 	isSpawned bool
 
+	// Spawned dependencies mutex.
+	spawnedDepsMu sync.Mutex
+
+	// Factories spawned for the factory, recorded at resolve time.
+	spawnedDeps []*factory
+
 	// Factory function type.
 	funcType reflect.Type
 
 	// Factory function value.
 	funcValue reflect.Value
 
+	// Factory typed call function or nil to call the function value via reflection.
+	callFn func(inValues []reflect.Value) []reflect.Value
+
 	// Factory input types.
 	inTypes []reflect.Type
 
@@ -154,6 +256,31 @@ type factory struct {
 	getOutErrorFn getOutErrorFn
 }
 
+// getScope returns the module scope of factory dependencies, nil stands for the top level.
+func (f *factory) getScope() *module {
+	if f == nil {
+		return nil
+	}
+	return f.module
+}
+
+// getTraceContext returns the context of the last traced factory invocation, or nil.
+func (f *factory) getTraceContext() context.Context {
+	if f == nil {
+		return nil
+	}
+	f.traceCtxMu.RLock()
+	defer f.traceCtxMu.RUnlock()
+	return f.traceCtx
+}
+
+// setTraceContext sets the context of the traced factory invocation.
+func (f *factory) setTraceContext(ctx context.Context) {
+	f.traceCtxMu.Lock()
+	defer f.traceCtxMu.Unlock()
+	f.traceCtx = ctx
+}
+
 // getIsSpawned returns factory spawned status in a thread-safe way.
 func (f *factory) getIsSpawned() bool {
 	f.isSpawnedMu.RLock()
@@ -168,6 +295,22 @@ func (f *factory) setIsSpawned(value bool) {
 	f.isSpawned = value
 }
 
+// addSpawnedDependency records the factory spawned for the factory in a thread-safe way.
+func (f *factory) addSpawnedDependency(dep *factory) {
+	f.spawnedDepsMu.Lock()
+	defer f.spawnedDepsMu.Unlock()
+	if !slices.Contains(f.spawnedDeps, dep) {
+		f.spawnedDeps = append(f.spawnedDeps, dep)
+	}
+}
+
+// getSpawnedDependencies returns factories spawned for the factory in a thread-safe way.
+func (f *factory) getSpawnedDependencies() []*factory {
+	f.spawnedDepsMu.Lock()
+	defer f.spawnedDepsMu.Unlock()
+	return slices.Clone(f.spawnedDeps)
+}
+
 // getOutValues returns factory output values in a thread-safe way.
 func (f *factory) getOutValues() []reflect.Value {
 	f.outValuesMu.RLock()
@@ -231,16 +374,12 @@ func (f *factory) getOutClose() func() error {
 
 	// Check if the value is valid.
 	if !outValue.IsValid() {
-		return func() error {
-			return nil
-		}
+		return f.getAutoClose()
 	}
 
 	// Check if the value is nil.
 	if outValue.IsNil() {
-		return func() error {
-			return nil
-		}
+		return f.getAutoClose()
 	}
 
 	// Check if the value is a close function.
@@ -254,6 +393,16 @@ func (f *factory) getOutClose() func() error {
 	}
 }
 
+// hasAnnotation returns true when the factory has an annotation assignable to the type.
+func (f *factory) hasAnnotation(annotationType reflect.Type) bool {
+	for _, annotation := range f.annotations {
+		if annotation != nil && reflect.TypeOf(annotation).AssignableTo(annotationType) {
+			return true
+		}
+	}
+	return false
+}
+
 // getOutTypeFn is the function type for getting an output type.
 type getOutTypeFn func([]reflect.Type) reflect.Type
 
@@ -0,0 +1,104 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gontainertest provides helpers for testing container wiring and services.
+//
+// Example:
+//
+//	func TestUserService(t *testing.T) {
+//	    c := gontainertest.New(t,
+//	        app.WithUserService(),
+//	        app.WithDatabase(),
+//	        gontainertest.Replace[Mailer](&fakeMailer{}),
+//	    )
+//
+//	    users := gontainertest.Resolve[*UserService](t, c)
+//	    ...
+//	}
+package gontainertest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/gontainer/v2"
+)
+
+// New creates a container for the test and closes it on the test cleanup.
+//
+// The test fails with the rendered traceback when the container
+// cannot be created or when closing of spawned factories fails.
+func New(t testing.TB, options ...gontainer.Option) *gontainer.Container {
+	t.Helper()
+
+	// Create the container.
+	container, err := gontainer.New(options...)
+	if err != nil {
+		t.Fatalf("failed to create container:\n\n%s", err)
+		return nil
+	}
+
+	// Close the container on the test cleanup.
+	t.Cleanup(func() {
+		if err := container.Close(); err != nil {
+			t.Errorf("failed to close container:\n\n%s", err)
+		}
+	})
+
+	// Container created.
+	return container
+}
+
+// Replace returns an option registering the service in place of all
+// other factories with the same output type.
+//
+// Example:
+//
+//	gontainertest.Replace[Mailer](&fakeMailer{})
+func Replace[T any](service T) gontainer.Option {
+	return gontainer.NewService(service, gontainer.WithOverride())
+}
+
+// Resolve resolves a service of type T from the container.
+//
+// The test fails with the rendered traceback when the service cannot be resolved.
+func Resolve[T any](t testing.TB, c *gontainer.Container) T {
+	t.Helper()
+
+	// Resolve the service.
+	result, err := gontainer.Resolve[T](c.Resolver())
+	if err != nil {
+		t.Fatalf("failed to resolve %s:\n\n%s", reflect.TypeOf((*T)(nil)).Elem(), err)
+	}
+
+	// Service resolved.
+	return result
+}
+
+// Invoke invokes the function with dependencies resolved from the container.
+//
+// The function must return either nothing or exactly one error. Optional arguments
+// are passed to the function as in Invoker.InvokeWith. The test fails with the
+// rendered traceback when the invocation fails or the function returns an error.
+func Invoke(t testing.TB, c *gontainer.Container, function any, args ...any) {
+	t.Helper()
+
+	// Invoke the function.
+	if err := gontainer.InvokeErr(c.Invoker(), function, args...); err != nil {
+		t.Fatalf("failed to invoke function:\n\n%s", err)
+	}
+}
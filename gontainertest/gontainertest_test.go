@@ -0,0 +1,160 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainertest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/gontainer/v2"
+)
+
+// TestHelpers tests container test helpers.
+func TestHelpers(t *testing.T) {
+	type mailer interface{ Send() string }
+
+	closed := false
+	t.Run("Container", func(t *testing.T) {
+		c := New(t,
+			gontainer.NewFactory(func() (mailer, func() error) {
+				return testMailer("smtp"), func() error {
+					closed = true
+					return nil
+				}
+			}),
+			gontainer.NewFactory(func() (string, func() error) {
+				return "string", func() error {
+					closed = true
+					return nil
+				}
+			}),
+			Replace[mailer](testMailer("fake")),
+		)
+
+		equal(t, Resolve[mailer](t, c).Send(), "fake")
+		equal(t, Resolve[string](t, c), "string")
+
+		invoked := false
+		Invoke(t, c, func(m mailer, n int) {
+			equal(t, m.Send(), "fake")
+			equal(t, n, 42)
+			invoked = true
+		}, 42)
+		equal(t, invoked, true)
+		equal(t, closed, false)
+	})
+	equal(t, closed, true)
+}
+
+// TestHelperFailures tests failures reported by test helpers.
+func TestHelperFailures(t *testing.T) {
+	t.Run("NewFailure", func(t *testing.T) {
+		ft := runFakeT(func(ft *fakeT) {
+			New(ft, gontainer.NewFactory(func(bool) string { return "" }))
+		})
+		equal(t, ft.fatal, true)
+		equal(t, strings.HasPrefix(ft.message, "failed to create container:\n\ndependency not resolved: bool"), true)
+	})
+
+	t.Run("CloseFailure", func(t *testing.T) {
+		ft := runFakeT(func(ft *fakeT) {
+			c := New(ft, gontainer.NewFactory(func() (string, func() error) {
+				return "string", func() error { return errors.New("close boom") }
+			}))
+			Resolve[string](ft, c)
+		})
+		equal(t, ft.fatal, false)
+		ft.cleanup()
+		equal(t, strings.HasPrefix(ft.message, "failed to close container:\n\nclose boom"), true)
+	})
+
+	t.Run("ResolveFailure", func(t *testing.T) {
+		ft := runFakeT(func(ft *fakeT) {
+			Resolve[int](ft, New(ft))
+		})
+		equal(t, ft.fatal, true)
+		equal(t, strings.HasPrefix(ft.message, "failed to resolve int:\n\ndependency not resolved: int"), true)
+	})
+
+	t.Run("InvokeFailure", func(t *testing.T) {
+		ft := runFakeT(func(ft *fakeT) {
+			Invoke(ft, New(ft), func() error { return errors.New("invoke boom") })
+		})
+		equal(t, ft.fatal, true)
+		equal(t, ft.message, "failed to invoke function:\n\ninvoke boom")
+	})
+}
+
+type testMailer string
+
+func (m testMailer) Send() string { return string(m) }
+
+// fakeT records test failures reported by helpers.
+type fakeT struct {
+	testing.TB
+	fatal    bool
+	message  string
+	cleanups []func()
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Fatalf(format string, args ...any) {
+	f.fatal = true
+	f.message = fmt.Sprintf(format, args...)
+	runtime.Goexit()
+}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.message = fmt.Sprintf(format, args...)
+}
+
+func (f *fakeT) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeT) cleanup() {
+	for index := len(f.cleanups) - 1; index >= 0; index-- {
+		f.cleanups[index]()
+	}
+}
+
+// runFakeT runs the function in a separate goroutine to allow Fatalf to exit it.
+func runFakeT(fn func(ft *fakeT)) *fakeT {
+	ft := &fakeT{}
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		fn(ft)
+	}()
+	wg.Wait()
+	return ft
+}
+
+func equal(t *testing.T, a, b any) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("equal failed: '%v' != '%v'", a, b)
+	}
+}
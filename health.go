@@ -0,0 +1,338 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// defaultHealthTimeout is the default timeout of a single health check.
+const defaultHealthTimeout = 5 * time.Second
+
+// HealthChecker is the interface for services reporting their health.
+type HealthChecker interface {
+	// HealthCheck returns an error when the service is not healthy.
+	HealthCheck(ctx context.Context) error
+}
+
+// WithHealthCheck returns an option that registers a health check of the factory service.
+//
+// The check takes precedence over the HealthChecker implementation of the service.
+// The factory registration fails when its service type is not assignable to T.
+//
+// Example:
+//
+//	gontainer.NewFactory(newDatabase, gontainer.WithHealthCheck(func(ctx context.Context, db *sql.DB) error {
+//	    return db.PingContext(ctx)
+//	}))
+func WithHealthCheck[T any](check func(ctx context.Context, service T) error) healthCheckOpt {
+	serviceType := reflect.TypeOf((*T)(nil)).Elem()
+	return healthCheckOpt{
+		check: func(ctx context.Context, service any) error {
+			typed, ok := service.(T)
+			if !ok {
+				return fmt.Errorf("health check of %s does not accept %T", serviceType, service)
+			}
+			return check(ctx, typed)
+		},
+		serviceType: serviceType,
+	}
+}
+
+// healthCheckOpt is a health check of the factory service.
+type healthCheckOpt struct {
+	check       func(ctx context.Context, service any) error
+	serviceType reflect.Type
+}
+
+// applyFactory applies the option to the factory settings.
+func (o healthCheckOpt) applyFactory(s *factorySettings) {
+	s.healthCheck = o.check
+	s.healthType = o.serviceType
+}
+
+// WithHealthTimeout returns a container option that sets the timeout of a single health check.
+//
+// The default timeout is 5 seconds.
+func WithHealthTimeout(timeout time.Duration) Option {
+	return healthTimeoutOpt{timeout: timeout}
+}
+
+// healthTimeoutOpt sets the health check timeout.
+type healthTimeoutOpt struct {
+	timeout time.Duration
+}
+
+// apply applies the health timeout option to the given registry.
+func (o healthTimeoutOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.healthTimeout = o.timeout
+	return nil
+}
+
+// HealthStatus is the status of a health check.
+type HealthStatus string
+
+const (
+	// HealthStatusUp reports a healthy service.
+	HealthStatusUp HealthStatus = "up"
+
+	// HealthStatusDown reports an unhealthy service.
+	HealthStatusDown HealthStatus = "down"
+)
+
+// HealthReport is the aggregated result of health checks.
+type HealthReport struct {
+	// Status is up when all checks are up.
+	Status HealthStatus `json:"status"`
+
+	// Checks are the results of checks in registration order of factories.
+	Checks []HealthCheckResult `json:"checks"`
+}
+
+// HealthCheckResult is the result of a single health check.
+type HealthCheckResult struct {
+	// Name is the human-readable name of the factory.
+	Name string `json:"name"`
+
+	// Type is the service type.
+	Type string `json:"type"`
+
+	// Module is the path of the enclosing module, or empty for top-level factories.
+	Module string `json:"module,omitempty"`
+
+	// Status is the status of the check.
+	Status HealthStatus `json:"status"`
+
+	// Error is the error message of a failed check.
+	Error string `json:"error,omitempty"`
+
+	// Duration is the duration of the check in nanoseconds.
+	Duration time.Duration `json:"duration"`
+}
+
+// Health aggregates health checks of spawned services.
+//
+// Checks of services implementing HealthChecker or registered with WithHealthCheck
+// are run concurrently, each with the timeout configured by WithHealthTimeout.
+// Services which have not been spawned yet are not checked.
+type Health struct {
+	registry *registry
+}
+
+// Liveness runs health checks of all spawned services.
+func (h *Health) Liveness(ctx context.Context) HealthReport {
+	return newHealthReport(h.registry.runHealthChecks(ctx))
+}
+
+// Readiness runs health checks of all spawned services and reports
+// the container down until its entrypoints are started.
+func (h *Health) Readiness(ctx context.Context) HealthReport {
+	results := h.registry.runHealthChecks(ctx)
+	if !h.registry.isStarted() {
+		results = append(results, HealthCheckResult{
+			Name:   "Container",
+			Status: HealthStatusDown,
+			Error:  "container not started",
+		})
+	}
+	return newHealthReport(results)
+}
+
+// LivenessHandler returns an HTTP handler emitting the JSON liveness report.
+//
+// Reports with the down status are served with the 503 Service Unavailable status code.
+//
+// Example:
+//
+//	mux.Handle("/healthz", health.LivenessHandler())
+func (h *Health) LivenessHandler() http.Handler {
+	return newHealthHandler(h.Liveness)
+}
+
+// ReadinessHandler returns an HTTP handler emitting the JSON readiness report.
+//
+// Reports with the down status are served with the 503 Service Unavailable status code.
+//
+// Example:
+//
+//	mux.Handle("/readyz", health.ReadinessHandler())
+func (h *Health) ReadinessHandler() http.Handler {
+	return newHealthHandler(h.Readiness)
+}
+
+// newHealthHandler returns an HTTP handler emitting the JSON health report of the function.
+func newHealthHandler(getReport func(ctx context.Context) HealthReport) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Prepare the health report.
+		report := getReport(req.Context())
+
+		// Write the health report.
+		w.Header().Set("Content-Type", "application/json")
+		if report.Status != HealthStatusUp {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+// newHealthReport aggregates results of health checks.
+func newHealthReport(results []HealthCheckResult) HealthReport {
+	report := HealthReport{Status: HealthStatusUp, Checks: results}
+	for _, result := range results {
+		if result.Status != HealthStatusUp {
+			report.Status = HealthStatusDown
+		}
+	}
+	if report.Checks == nil {
+		report.Checks = []HealthCheckResult{}
+	}
+	return report
+}
+
+// runHealthChecks runs health checks of spawned factories concurrently.
+func (r *registry) runHealthChecks(ctx context.Context) []HealthCheckResult {
+	// Prepare the check timeout.
+	r.mutex.Lock()
+	timeout := r.healthTimeout
+	r.mutex.Unlock()
+	if timeout <= 0 {
+		timeout = defaultHealthTimeout
+	}
+
+	// Collect checks of spawned factories.
+	var facts []*factory
+	var checks []func(ctx context.Context) error
+	for _, fact := range r.factories {
+		if check := fact.getHealthCheck(); check != nil {
+			facts = append(facts, fact)
+			checks = append(checks, check)
+		}
+	}
+
+	// Run all checks concurrently.
+	results := make([]HealthCheckResult, len(checks))
+	var wg sync.WaitGroup
+	for index := range checks {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			results[index] = runHealthCheck(ctx, facts[index], checks[index], timeout)
+		}(index)
+	}
+	wg.Wait()
+
+	// Return results in registration order.
+	return results
+}
+
+// runHealthCheck runs a single health check with the timeout.
+func runHealthCheck(ctx context.Context, fact *factory, check func(ctx context.Context) error, timeout time.Duration) HealthCheckResult {
+	// Prepare the check result.
+	result := HealthCheckResult{
+		Name:   fact.name,
+		Type:   fact.getOutType().String(),
+		Status: HealthStatusUp,
+	}
+	if fact.module != nil {
+		result.Module = fact.module.path
+	}
+
+	// Run the check in a goroutine to respect the timeout.
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	started := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	// Wait for the check or the timeout.
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	result.Duration = time.Since(started)
+
+	// Report the failed check.
+	if err != nil {
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// validateHealthCheck returns an error when the health check does not accept the factory service.
+func (f *factory) validateHealthCheck() error {
+	if f.healthCheck == nil {
+		return nil
+	}
+	if outType := f.getOutType(); !outType.AssignableTo(f.healthType) {
+		return fmt.Errorf("health check of %s does not accept %s", f.healthType, outType)
+	}
+	return nil
+}
+
+// getHealthCheck returns the health check of the spawned factory service or nil.
+func (f *factory) getHealthCheck() func(ctx context.Context) error {
+	// Services which have not been spawned are not checked.
+	outValue := f.getOutValue()
+	if !outValue.IsValid() || isNilValue(outValue) || f.getOutError() != nil {
+		return nil
+	}
+	service := outValue.Interface()
+
+	// Prefer the check registered with the option.
+	if f.healthCheck != nil {
+		return func(ctx context.Context) error {
+			return f.healthCheck(ctx, service)
+		}
+	}
+
+	// Use the checker implemented by the service.
+	if checker, ok := service.(HealthChecker); ok {
+		return checker.HealthCheck
+	}
+	return nil
+}
+
+// isStarted returns true when the container entrypoints are started.
+func (r *registry) isStarted() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.started
+}
+
+// setStarted marks the container entrypoints as started.
+func (r *registry) setStarted() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.started = true
+}
@@ -0,0 +1,139 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testHealthy implements HealthChecker.
+type testHealthy struct{ err error }
+
+func (h *testHealthy) HealthCheck(ctx context.Context) error { return h.err }
+
+// testSlow implements HealthChecker blocking until the context is done.
+type testSlow struct{}
+
+func (s *testSlow) HealthCheck(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+// TestHealth tests aggregation of health checks.
+func TestHealth(t *testing.T) {
+	var report HealthReport
+	equal(t, Run(
+		WithHealthTimeout(10*time.Millisecond),
+		NewService(&testHealthy{}),
+		NewModule("db", NewService(&testFmtLeaf{}, WithHealthCheck(func(ctx context.Context, leaf *testFmtLeaf) error {
+			equal(t, leaf != nil, true)
+			return errors.New("connection refused")
+		}))),
+		NewService(&testSlow{}),
+		NewService(&testFmtMid{}, WithHealthCheck(func(context.Context, *testFmtMid) error {
+			t.Error("unspawned service checked")
+			return nil
+		})),
+		NewEntrypoint(func(health *Health, _ *testHealthy, _ *testFmtLeaf, _ *testSlow) {
+			report = health.Liveness(context.Background())
+		}),
+	), nil)
+
+	equal(t, report.Status, HealthStatusDown)
+	equal(t, len(report.Checks), 3)
+
+	equal(t, report.Checks[0].Name, "Service[*gontainer.testHealthy]")
+	equal(t, report.Checks[0].Type, "*gontainer.testHealthy")
+	equal(t, report.Checks[0].Status, HealthStatusUp)
+	equal(t, report.Checks[0].Error, "")
+
+	equal(t, report.Checks[1].Module, "db")
+	equal(t, report.Checks[1].Status, HealthStatusDown)
+	equal(t, report.Checks[1].Error, "connection refused")
+
+	equal(t, report.Checks[2].Status, HealthStatusDown)
+	equal(t, report.Checks[2].Error, context.DeadlineExceeded.Error())
+}
+
+// TestHealthHandler tests the HTTP handler of health reports.
+func TestHealthHandler(t *testing.T) {
+	container, err := New(
+		NewService(&testHealthy{}),
+		NewEntrypoint(func(*testHealthy) {}),
+	)
+	equal(t, err, nil)
+	liveness := container.Health().LivenessHandler()
+	readiness := container.Health().ReadinessHandler()
+
+	// Serve the request and decode the report.
+	serve := func(handler http.Handler, path string) (int, HealthReport) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		equal(t, recorder.Header().Get("Content-Type"), "application/json")
+
+		var report HealthReport
+		equal(t, json.Unmarshal(recorder.Body.Bytes(), &report), nil)
+		return recorder.Code, report
+	}
+
+	// Nothing is spawned before the start.
+	code, report := serve(liveness, "/healthz")
+	equal(t, code, http.StatusOK)
+	equal(t, report, HealthReport{Status: HealthStatusUp, Checks: []HealthCheckResult{}})
+
+	// The container is not ready before the start, wherever the handler is mounted.
+	code, report = serve(readiness, "/health/ready")
+	equal(t, code, http.StatusServiceUnavailable)
+	equal(t, report.Checks, []HealthCheckResult{{
+		Name: "Container", Status: HealthStatusDown, Error: "container not started",
+	}})
+
+	// The container is ready after the start.
+	equal(t, container.Start(), nil)
+	code, report = serve(readiness, "/readyz")
+	equal(t, code, http.StatusOK)
+	equal(t, len(report.Checks), 1)
+	equal(t, report.Checks[0].Status, HealthStatusUp)
+	equal(t, container.Close(), nil)
+}
+
+// TestHealthCheckType tests validation of the health check service type.
+func TestHealthCheckType(t *testing.T) {
+	check := func(context.Context, HealthChecker) error { return nil }
+
+	// Services assignable to the check type are accepted.
+	equal(t, Run(
+		NewService(&testHealthy{}, WithHealthCheck(check)),
+		NewFactory(func() *testSlow { return &testSlow{} }, WithHealthCheck(check)),
+		NewEntrypoint(func() {}),
+	), nil)
+
+	// Services not assignable to the check type are rejected.
+	err := Run(NewService("string", WithHealthCheck(check)))
+	equal(t, err.Error(), "failed to load Service[string]: "+
+		"health check of gontainer.HealthChecker does not accept string")
+	err = Run(NewFactory(func() *testFmtLeaf { return nil }, WithHealthCheck(check)))
+	equal(t, err.Error(), "failed to load Factory[func() *gontainer.testFmtLeaf]: "+
+		"health check of gontainer.HealthChecker does not accept *gontainer.testFmtLeaf")
+}
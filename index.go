@@ -0,0 +1,98 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"reflect"
+	"sync"
+)
+
+// typeIndex is an index of factories by provided service types.
+//
+// Factories of concrete types are indexed by the exact output type on creation,
+// factories implementing an interface type are collected on the first lookup
+// of the interface and cached for subsequent lookups.
+type typeIndex struct {
+	// Indexed factories in registration order.
+	factories []*factory
+
+	// Factories by the exact output type.
+	exact map[reflect.Type][]*factory
+
+	// Factories by implemented interface types.
+	implementations map[reflect.Type][]*factory
+
+	// Implementations cache mutex.
+	mutex sync.RWMutex
+}
+
+// newTypeIndex creates a new index of the factories.
+func newTypeIndex(factories []*factory) *typeIndex {
+	index := &typeIndex{
+		factories:       factories,
+		exact:           make(map[reflect.Type][]*factory, len(factories)),
+		implementations: map[reflect.Type][]*factory{},
+	}
+	for _, fact := range factories {
+		if outType := fact.getOutType(); outType != nil {
+			index.exact[outType] = append(index.exact[outType], fact)
+		}
+	}
+	return index
+}
+
+// lookup returns factories providing the service type in registration order.
+//
+// The returned slice is shared between lookups and must not be modified.
+func (i *typeIndex) lookup(serviceType reflect.Type) []*factory {
+	// Only factories of the exact type provide a concrete type.
+	if serviceType.Kind() != reflect.Interface {
+		return i.exact[serviceType]
+	}
+
+	// Lookup for cached interface implementations.
+	i.mutex.RLock()
+	factories, ok := i.implementations[serviceType]
+	i.mutex.RUnlock()
+	if ok {
+		return factories
+	}
+
+	// Collect factories implementing the interface.
+	for _, fact := range i.factories {
+		if isTypeProvided(fact.getOutType(), serviceType) {
+			factories = append(factories, fact)
+		}
+	}
+
+	// Cache interface implementations.
+	i.mutex.Lock()
+	i.implementations[serviceType] = factories
+	i.mutex.Unlock()
+	return factories
+}
+
+// getIndex returns the type index of registered factories, building it when necessary.
+func (r *registry) getIndex() *typeIndex {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.index == nil {
+		r.index = newTypeIndex(r.factories)
+	}
+	return r.index
+}
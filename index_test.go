@@ -0,0 +1,119 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// TestTypeIndex tests lookups of the type index.
+func TestTypeIndex(t *testing.T) {
+	registry := &registry{}
+	equal(t, NewService(&testStringer{}).apply(registry), nil)
+	equal(t, NewService(1).apply(registry), nil)
+	equal(t, NewService[fmt.Stringer](&testStringer{}).apply(registry), nil)
+
+	stringerType := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	equal(t, len(registry.findFactories(reflect.TypeOf(1), nil)), 1)
+	equal(t, len(registry.findFactories(reflect.TypeOf(""), nil)), 0)
+	equal(t, len(registry.findFactories(reflect.TypeOf(&testStringer{}), nil)), 1)
+	equal(t, registry.findFactories(stringerType, nil), []*factory{
+		registry.factories[0], registry.factories[2],
+	})
+
+	// Registration invalidates the index.
+	equal(t, NewService(&testStringer{}).apply(registry), nil)
+	equal(t, len(registry.findFactories(stringerType, nil)), 3)
+	equal(t, len(registry.findFactories(reflect.TypeOf(&testStringer{}), nil)), 2)
+}
+
+// newBenchmarkOptions returns options of layers of factories of distinct types with every
+// factory depending on all factories of the previous layer, a single-width graph is a chain,
+// and an entrypoint depending on the last layer.
+func newBenchmarkOptions(layers, width int) []Option {
+	// Prepare distinct service types.
+	types := make([][]reflect.Type, layers)
+	for layer := range types {
+		types[layer] = make([]reflect.Type, width)
+		for index := range types[layer] {
+			types[layer][index] = reflect.ArrayOf(layer*width+index, reflect.TypeOf(byte(0)))
+		}
+	}
+
+	// Prepare factories of all layers.
+	options := make([]Option, 0, layers*width+1)
+	for layer, layerTypes := range types {
+		var inTypes []reflect.Type
+		if layer > 0 {
+			inTypes = types[layer-1]
+		}
+		for _, outType := range layerTypes {
+			outValue := reflect.Zero(outType)
+			funcType := reflect.FuncOf(inTypes, []reflect.Type{outType}, false)
+			function := reflect.MakeFunc(funcType, func([]reflect.Value) []reflect.Value {
+				return []reflect.Value{outValue}
+			})
+			options = append(options, NewFactory(function.Interface()))
+		}
+	}
+
+	// Prepare the entrypoint depending on the last layer.
+	funcType := reflect.FuncOf(types[layers-1], nil, false)
+	function := reflect.MakeFunc(funcType, func([]reflect.Value) []reflect.Value { return nil })
+	return append(options, NewEntrypoint(function.Interface()))
+}
+
+// BenchmarkFindFactories benchmarks lookups of concrete and interface types.
+func BenchmarkFindFactories(b *testing.B) {
+	for _, size := range []int{10, 100, 1000} {
+		container, err := New(newBenchmarkOptions(size, 1)...)
+		if err != nil {
+			b.Fatal(err)
+		}
+		registry := container.registry
+		concreteType := reflect.ArrayOf(size/2, reflect.TypeOf(byte(0)))
+		interfaceType := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+		b.Run(fmt.Sprintf("Concrete/%d", size), func(b *testing.B) {
+			for index := 0; index < b.N; index++ {
+				registry.findFactories(concreteType, nil)
+			}
+		})
+		b.Run(fmt.Sprintf("Interface/%d", size), func(b *testing.B) {
+			for index := 0; index < b.N; index++ {
+				registry.findFactories(interfaceType, nil)
+			}
+		})
+	}
+}
+
+// BenchmarkRun benchmarks validation and resolution of all factories.
+func BenchmarkRun(b *testing.B) {
+	for _, size := range []int{10, 100, 500} {
+		options := newBenchmarkOptions(size, 1)
+		b.Run(fmt.Sprintf("Factories/%d", size), func(b *testing.B) {
+			for index := 0; index < b.N; index++ {
+				if err := Run(options...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
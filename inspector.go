@@ -0,0 +1,159 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Inspector provides runtime information about registered factories and entrypoints.
+//
+// The Factories and Entrypoints methods return snapshots describing every registration
+// in the container, including annotations attached with WithAnnotation, input dependencies,
+// and whether a factory has already been spawned.
+//
+// This is useful for annotation-driven routing, admin endpoints, and diagnostics.
+type Inspector struct {
+	registry *registry
+}
+
+// FactoryInfo describes a registered factory or entrypoint.
+type FactoryInfo struct {
+	// Name is the human-readable name of the factory.
+	Name string
+
+	// Source is the "<file>:<line>" location of the factory declaration.
+	Source string
+
+	// Module is the path of the enclosing module, or empty for top-level factories.
+	Module string
+
+	// Private reports whether the service is not exported from its modules.
+	Private bool
+
+	// Conditions are the enclosing conditions deciding on the registration, outermost first.
+	Conditions []ConditionInfo
+
+	// Profiles are the profiles of the factory.
+	Profiles []string
+
+	// Active reports whether the factory is registered by its conditions and profiles.
+	Active bool
+
+	// Builtin reports whether the factory is a service provided by the container itself.
+	Builtin bool
+
+	// Type is the output service type, or nil for entrypoints.
+	Type reflect.Type
+
+	// Annotations are the values attached with WithAnnotation.
+	Annotations []any
+
+	// Eager reports whether the factory is spawned before entrypoints are invoked.
+	Eager bool
+
+	// Spawned reports whether the factory has been spawned, always false for entrypoints.
+	Spawned bool
+
+	// Dependencies are the input types of the factory function.
+	Dependencies []reflect.Type
+}
+
+// Factories returns descriptions of all registered factories in registration order.
+func (i *Inspector) Factories() []FactoryInfo {
+	return newFactoryInfos(i.registry.factories)
+}
+
+// Entrypoints returns descriptions of all registered entrypoints in registration order.
+func (i *Inspector) Entrypoints() []FactoryInfo {
+	return newFactoryInfos(i.registry.entrypoints)
+}
+
+// Inactive returns descriptions of factories and entrypoints skipped by conditions or profiles.
+func (i *Inspector) Inactive() []FactoryInfo {
+	return newFactoryInfos(i.registry.inactive)
+}
+
+// Unused returns descriptions of factories not reachable from any entrypoint.
+//
+// A factory is reachable when it is eager, or when an entrypoint or an eager factory depends
+// on its service directly or transitively through regular, Optional, Multiple or Tagged dependencies.
+func (i *Inspector) Unused() []FactoryInfo {
+	return newFactoryInfos(i.registry.findUnusedFactories())
+}
+
+// Unspawned returns descriptions of factories which have not been spawned yet.
+func (i *Inspector) Unspawned() []FactoryInfo {
+	return newFactoryInfos(i.registry.findUnspawnedFactories())
+}
+
+// FindByAnnotation returns descriptions of factories annotated with a value assignable to A.
+//
+// Example:
+//
+//	for _, info := range gontainer.FindByAnnotation[RouteGroup](inspector) { ... }
+func FindByAnnotation[A any](i *Inspector) []FactoryInfo {
+	annotationType := reflect.TypeOf((*A)(nil)).Elem()
+
+	// Filter factories by the annotation type.
+	var results []FactoryInfo
+	for _, fact := range i.registry.factories {
+		if fact.hasAnnotation(annotationType) {
+			results = append(results, newFactoryInfo(fact))
+		}
+	}
+
+	// Return matched factories.
+	return results
+}
+
+// newFactoryInfos returns descriptions of the specified factories.
+func newFactoryInfos(factories []*factory) []FactoryInfo {
+	results := make([]FactoryInfo, 0, len(factories))
+	for _, fact := range factories {
+		results = append(results, newFactoryInfo(fact))
+	}
+	return results
+}
+
+// newFactoryInfo returns a description of the specified factory.
+func newFactoryInfo(fact *factory) FactoryInfo {
+	// Prepare the module path.
+	var modulePath string
+	if fact.module != nil {
+		modulePath = fact.module.path
+	}
+
+	// Prepare the factory description.
+	return FactoryInfo{
+		Name:         fact.name,
+		Source:       fact.source,
+		Module:       modulePath,
+		Private:      fact.kind == kindFactory && !fact.isVisibleFrom(nil),
+		Conditions:   fact.condition.getInfos(),
+		Profiles:     slices.Clone(fact.profiles),
+		Active:       fact.active,
+		Builtin:      fact.builtin,
+		Eager:        fact.eager,
+		Type:         fact.getOutType(),
+		Annotations:  slices.Clone(fact.annotations),
+		Spawned:      fact.getIsSpawned(),
+		Dependencies: slices.Clone(fact.inTypes),
+	}
+}
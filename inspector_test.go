@@ -0,0 +1,100 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestInspectorService tests inspector service.
+func TestInspectorService(t *testing.T) {
+	type routeGroup struct{ prefix string }
+
+	// Prepare started flag.
+	started := atomic.Bool{}
+
+	// Run container.
+	equal(t, Run(
+		NewFactory(func() string { return "string" }, WithAnnotation(routeGroup{prefix: "/api"})),
+		NewFactory(func(s string) int { return len(s) }, WithAnnotation("plain")),
+		NewService(true),
+		NewEntrypoint(func(inspector *Inspector, s string) {
+			started.Store(true)
+
+			// Skip built-in services.
+			factories := inspector.Factories()
+			equal(t, factories[0].Type, reflect.TypeOf(&Resolver{}))
+			equal(t, factories[0].Builtin, true)
+			factories = withoutBuiltins(factories)
+			equal(t, len(factories), 3)
+			equal(t, factories[0].Builtin, false)
+
+			equal(t, factories[0].Name, "Factory[func() string]")
+			equal(t, factories[0].Type, reflect.TypeOf(""))
+			equal(t, factories[0].Annotations, []any{routeGroup{prefix: "/api"}})
+			equal(t, factories[0].Spawned, true)
+			equal(t, len(factories[0].Dependencies), 0)
+			equal(t, strings.Contains(factories[0].Source, "inspector_test.go:"), true)
+
+			equal(t, factories[1].Name, "Factory[func(string) int]")
+			equal(t, factories[1].Type, reflect.TypeOf(0))
+			equal(t, factories[1].Annotations, []any{"plain"})
+			equal(t, factories[1].Spawned, false)
+			equal(t, factories[1].Dependencies, []reflect.Type{reflect.TypeOf("")})
+
+			equal(t, factories[2].Name, "Service[bool]")
+			equal(t, factories[2].Annotations, []any(nil))
+			equal(t, factories[2].Spawned, false)
+
+			entrypoints := inspector.Entrypoints()
+			equal(t, len(entrypoints), 1)
+			equal(t, entrypoints[0].Type, nil)
+			equal(t, entrypoints[0].Dependencies, []reflect.Type{
+				reflect.TypeOf(&Inspector{}), reflect.TypeOf(""),
+			})
+
+			found := FindByAnnotation[routeGroup](inspector)
+			equal(t, len(found), 1)
+			equal(t, found[0].Name, "Factory[func() string]")
+
+			found = FindByAnnotation[fmt.Stringer](inspector)
+			equal(t, len(found), 0)
+
+			found = FindByAnnotation[any](inspector)
+			equal(t, len(found), 2)
+		}),
+	), nil)
+
+	// Assert started flag is set.
+	equal(t, started.Load(), true)
+}
+
+// withoutBuiltins returns descriptions of factories except built-in services.
+func withoutBuiltins(infos []FactoryInfo) []FactoryInfo {
+	results := make([]FactoryInfo, 0, len(infos))
+	for _, info := range infos {
+		if !info.Builtin {
+			results = append(results, info)
+		}
+	}
+	return results
+}
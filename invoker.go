@@ -18,6 +18,7 @@
 package gontainer
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 )
@@ -30,6 +31,9 @@ import (
 // If the container has not been started yet, dependency resolution happens in lazy mode — only
 // the required arguments and their transitive dependencies are instantiated on demand.
 //
+// The InvokeWith method additionally accepts explicit arguments which take precedence
+// over the container services of the same type, e.g. a per-request value passed to a handler.
+//
 // The Invoke method returns:
 //   - []any - all values returned by the function (including any errors)
 //   - error - only if dependency resolution fails or fn is not a function
@@ -38,11 +42,30 @@ import (
 // including any error values. The caller is responsible for checking and handling
 // these values as appropriate.
 type Invoker struct {
-	registry *registry
+	registry  *registry
+	requester *factory
 }
 
 // Invoke invokes specified function.
 func (i *Invoker) Invoke(function any) ([]any, error) {
+	return i.InvokeWith(function)
+}
+
+// InvokeWith invokes specified function using explicitly provided arguments.
+//
+// Each function parameter is taken from the first unused argument assignable to
+// the parameter type; parameters without a matching argument are resolved from
+// the container. An error is returned if any of the arguments was not used.
+//
+// Example:
+//
+//	invoker.InvokeWith(func(req *Request, db *Database) error { ... }, req)
+func (i *Invoker) InvokeWith(function any, args ...any) ([]any, error) {
+	// Validate the invoker is created by a container.
+	if i == nil || i.registry == nil {
+		return nil, errors.New("invalid invoker: not created by a container")
+	}
+
 	// Get reflection of the function.
 	funcValue := reflect.ValueOf(function)
 	funcType := reflect.TypeOf(function)
@@ -50,16 +73,44 @@ func (i *Invoker) Invoke(function any) ([]any, error) {
 		return nil, fmt.Errorf("invalid type: %v", funcType)
 	}
 
+	// Get reflection of the explicit arguments.
+	argValues := make([]reflect.Value, 0, len(args))
+	for _, arg := range args {
+		if arg == nil {
+			return nil, fmt.Errorf("invalid argument: %v", arg)
+		}
+		argValues = append(argValues, reflect.ValueOf(arg))
+	}
+
 	// Resolve function arguments.
+	argUsed := make([]bool, len(argValues))
 	inArgs := make([]reflect.Value, 0, funcType.NumIn())
 	for index := 0; index < funcType.NumIn(); index++ {
-		result, err := i.registry.resolveService(funcType.In(index))
+		inType := funcType.In(index)
+
+		// Prefer explicitly provided arguments.
+		argIndex := findArgument(argValues, argUsed, inType)
+		if argIndex >= 0 {
+			argUsed[argIndex] = true
+			inArgs = append(inArgs, argValues[argIndex])
+			continue
+		}
+
+		// Resolve the argument from the container.
+		result, err := i.registry.resolveService(inType, i.requester)
 		if err != nil {
 			return nil, err
 		}
 		inArgs = append(inArgs, result)
 	}
 
+	// Validate all explicit arguments were used.
+	for index, used := range argUsed {
+		if !used {
+			return nil, fmt.Errorf("unused argument: %s", argValues[index].Type())
+		}
+	}
+
 	// Call the function and collect results.
 	outArgs := funcValue.Call(inArgs)
 	results := make([]any, 0, len(outArgs))
@@ -69,3 +120,113 @@ func (i *Invoker) Invoke(function any) ([]any, error) {
 
 	return results, nil
 }
+
+// findArgument returns index of the first unused argument assignable to the type, or -1.
+func findArgument(argValues []reflect.Value, argUsed []bool, inType reflect.Type) int {
+	// Exact type matches take precedence over interface implementations.
+	for index, argValue := range argValues {
+		if !argUsed[index] && argValue.Type() == inType {
+			return index
+		}
+	}
+	for index, argValue := range argValues {
+		if !argUsed[index] && argValue.Type().AssignableTo(inType) {
+			return index
+		}
+	}
+	return -1
+}
+
+// Invoke1 invokes the function and returns its result as a value of type R.
+//
+// The function must return exactly one value assignable to R, optionally followed
+// by an error. A non-nil error returned by the function is returned as is.
+// Optional arguments are passed to the function as in InvokeWith.
+//
+// Example:
+//
+//	user, err := gontainer.Invoke1[*User](invoker, func(db *Database) (*User, error) { ... })
+func Invoke1[R any](i *Invoker, function any, args ...any) (R, error) {
+	var result R
+
+	// Validate the function signature.
+	funcType := reflect.TypeOf(function)
+	resultType := reflect.TypeOf(&result).Elem()
+	if funcType == nil || funcType.Kind() != reflect.Func {
+		return result, fmt.Errorf("invalid type: %v", funcType)
+	}
+	switch {
+	// Function returns exactly one value.
+	case funcType.NumOut() == 1 && funcType.Out(0).AssignableTo(resultType):
+
+	// Function returns a value and an error.
+	case funcType.NumOut() == 2 && funcType.Out(0).AssignableTo(resultType) && isErrorInterface(funcType.Out(1)):
+
+	// Function signature is invalid.
+	default:
+		return result, fmt.Errorf("invalid signature: %s", funcType)
+	}
+
+	// Invoke the function.
+	values, err := i.InvokeWith(function, args...)
+	if err != nil {
+		return result, err
+	}
+
+	// Handle error returned by the function.
+	if len(values) == 2 {
+		if err, _ := values[1].(error); err != nil {
+			return result, err
+		}
+	}
+
+	// Nil interface values are left as zero values, other values are assigned
+	// by reflection to convert assignable types, e.g. `chan T` to `<-chan T`.
+	if values[0] != nil {
+		reflect.ValueOf(&result).Elem().Set(reflect.ValueOf(values[0]))
+	}
+	return result, nil
+}
+
+// InvokeErr invokes the function and returns the error it returned, if any.
+//
+// The function must return either nothing or exactly one error.
+// Optional arguments are passed to the function as in InvokeWith.
+//
+// Example:
+//
+//	err := gontainer.InvokeErr(invoker, func(db *Database) error { ... })
+func InvokeErr(i *Invoker, function any, args ...any) error {
+	// Validate the function signature.
+	funcType := reflect.TypeOf(function)
+	if funcType == nil || funcType.Kind() != reflect.Func {
+		return fmt.Errorf("invalid type: %v", funcType)
+	}
+	switch {
+	// Function returns nothing.
+	case funcType.NumOut() == 0:
+
+	// Function returns an error.
+	case funcType.NumOut() == 1 && isErrorInterface(funcType.Out(0)):
+
+	// Function signature is invalid.
+	default:
+		return fmt.Errorf("invalid signature: %s", funcType)
+	}
+
+	// Invoke the function.
+	values, err := i.InvokeWith(function, args...)
+	if err != nil {
+		return err
+	}
+
+	// Handle error returned by the function.
+	if len(values) == 1 {
+		if err, _ := values[0].(error); err != nil {
+			return err
+		}
+	}
+
+	// Function invoked successfully.
+	return nil
+}
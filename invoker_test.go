@@ -19,6 +19,7 @@ package gontainer
 
 import (
 	"errors"
+	"fmt"
 	"sync/atomic"
 	"testing"
 )
@@ -129,3 +130,232 @@ func TestInvokerService(t *testing.T) {
 		})
 	}
 }
+
+// TestInvokeGeneric tests generic invoke helpers.
+func TestInvokeGeneric(t *testing.T) {
+	tests := []struct {
+		name   string
+		haveFn func(t *testing.T, invoker *Invoker)
+	}{
+		{
+			name: "Invoke1ReturnValue",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				value, err := Invoke1[string](invoker, func(s string, i int) string {
+					return s + "-X"
+				})
+				equal(t, err, nil)
+				equal(t, value, "string-X")
+			},
+		},
+		{
+			name: "Invoke1ReturnInterface",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				value, err := Invoke1[fmt.Stringer](invoker, func() *testStringer {
+					return &testStringer{}
+				})
+				equal(t, err, nil)
+				equal(t, value.String(), "stringer")
+			},
+		},
+		{
+			name: "Invoke1ReturnNilInterface",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				value, err := Invoke1[fmt.Stringer](invoker, func() fmt.Stringer {
+					return nil
+				})
+				equal(t, err, nil)
+				equal(t, value, nil)
+			},
+		},
+		{
+			name: "Invoke1ReturnAssignable",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				type testSlice []int
+				slice, err := Invoke1[[]int](invoker, func() testSlice {
+					return testSlice{1, 2}
+				})
+				equal(t, err, nil)
+				equal(t, slice, []int{1, 2})
+
+				channel := make(chan int, 1)
+				channel <- 3
+				received, err := Invoke1[<-chan int](invoker, func() chan int {
+					return channel
+				})
+				equal(t, err, nil)
+				equal(t, <-received, 3)
+			},
+		},
+		{
+			name: "Invoke1ReturnError",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				value, err := Invoke1[int](invoker, func(i int) (int, error) {
+					return i, errors.New("failed")
+				})
+				equal(t, err.Error(), "failed")
+				equal(t, value, 0)
+			},
+		},
+		{
+			name: "Invoke1InvalidSignature",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				_, err := Invoke1[int](invoker, func() string { return "" })
+				equal(t, err.Error(), "invalid signature: func() string")
+				_, err = Invoke1[int](invoker, func() (int, int) { return 0, 0 })
+				equal(t, err.Error(), "invalid signature: func() (int, int)")
+				_, err = Invoke1[int](invoker, 42)
+				equal(t, err.Error(), "invalid type: int")
+				_, err = Invoke1[int](invoker, nil)
+				equal(t, err.Error(), "invalid type: <nil>")
+			},
+		},
+		{
+			name: "Invoke1DependencyNotResolved",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				_, err := Invoke1[int](invoker, func(bool) int { return 0 })
+				equal(t, errors.Is(err, ErrDependencyNotResolved), true)
+			},
+		},
+		{
+			name: "InvokeErrReturnNothing",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				invoked := false
+				equal(t, InvokeErr(invoker, func(string) { invoked = true }), nil)
+				equal(t, invoked, true)
+			},
+		},
+		{
+			name: "InvokeErrReturnError",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				err := InvokeErr(invoker, func(int) error { return errors.New("failed") })
+				equal(t, err.Error(), "failed")
+				equal(t, InvokeErr(invoker, func(int) error { return nil }), nil)
+			},
+		},
+		{
+			name: "InvokeErrInvalidSignature",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				err := InvokeErr(invoker, func() int { return 0 })
+				equal(t, err.Error(), "invalid signature: func() int")
+				equal(t, InvokeErr(nil, func() {}) != nil, true)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal(t, Run(
+				NewFactory(func() string { return "string" }),
+				NewFactory(func() int { return 123 }),
+				NewEntrypoint(func(invoker *Invoker) {
+					tt.haveFn(t, invoker)
+				}),
+			), nil)
+		})
+	}
+}
+
+type testStringer struct{}
+
+func (s *testStringer) String() string { return "stringer" }
+
+// TestInvokerNotCreated tests invokers not created by a container.
+func TestInvokerNotCreated(t *testing.T) {
+	_, err := (&Invoker{}).Invoke(func() {})
+	equal(t, err.Error(), "invalid invoker: not created by a container")
+	_, err = Invoke1[string](&Invoker{}, func() string { return "" })
+	equal(t, err.Error(), "invalid invoker: not created by a container")
+	equal(t, InvokeErr(nil, func() {}).Error(), "invalid invoker: not created by a container")
+}
+
+// TestInvokerInvokeWith tests invoking functions with explicit arguments.
+func TestInvokerInvokeWith(t *testing.T) {
+	type request struct{ path string }
+
+	tests := []struct {
+		name   string
+		haveFn func(t *testing.T, invoker *Invoker)
+	}{
+		{
+			name: "ExplicitAndResolvedArguments",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				values, err := invoker.InvokeWith(func(req *request, s string) string {
+					return req.path + "-" + s
+				}, &request{path: "/home"})
+				equal(t, err, nil)
+				equal(t, values, []any{"/home-string"})
+			},
+		},
+		{
+			name: "ExplicitArgumentTakesPrecedence",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				values, err := invoker.InvokeWith(func(s string, i int) (string, int) {
+					return s, i
+				}, "explicit")
+				equal(t, err, nil)
+				equal(t, values, []any{"explicit", 123})
+			},
+		},
+		{
+			name: "ArgumentsOfTheSameTypeInOrder",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				values, err := invoker.InvokeWith(func(a, b string) string {
+					return a + b
+				}, "a", "b")
+				equal(t, err, nil)
+				equal(t, values, []any{"ab"})
+			},
+		},
+		{
+			name: "ArgumentImplementsInterface",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				values, err := invoker.InvokeWith(func(s fmt.Stringer) string {
+					return s.String()
+				}, &testStringer{})
+				equal(t, err, nil)
+				equal(t, values, []any{"stringer"})
+			},
+		},
+		{
+			name: "UnusedArgumentReturnsError",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				invoked := false
+				_, err := invoker.InvokeWith(func(string) { invoked = true }, true)
+				equal(t, err.Error(), "unused argument: bool")
+				equal(t, invoked, false)
+			},
+		},
+		{
+			name: "NilArgumentReturnsError",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				_, err := invoker.InvokeWith(func(string) {}, nil)
+				equal(t, err.Error(), "invalid argument: <nil>")
+			},
+		},
+		{
+			name: "GenericHelpersAcceptArguments",
+			haveFn: func(t *testing.T, invoker *Invoker) {
+				value, err := Invoke1[string](invoker, func(req *request) string {
+					return req.path
+				}, &request{path: "/health"})
+				equal(t, err, nil)
+				equal(t, value, "/health")
+
+				err = InvokeErr(invoker, func(req *request, i int) error {
+					return fmt.Errorf("%s-%d", req.path, i)
+				}, &request{path: "/fail"})
+				equal(t, err.Error(), "/fail-123")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equal(t, Run(
+				NewFactory(func() string { return "string" }),
+				NewFactory(func() int { return 123 }),
+				NewEntrypoint(func(invoker *Invoker) {
+					tt.haveFn(t, invoker)
+				}),
+			), nil)
+		})
+	}
+}
@@ -0,0 +1,333 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package metrics exposes container metrics in the Prometheus text format.
+//
+// The collector observes container events and exposes factory spawn and close
+// durations, failures and entrypoint restarts without external dependencies.
+//
+// Example:
+//
+//	collector := metrics.New()
+//	err := gontainer.Run(
+//	    collector.Option(),
+//	    gontainer.NewService(collector),
+//	    gontainer.NewEntrypoint(func(collector *metrics.Collector) error {
+//	        http.Handle("/metrics", collector.Handler())
+//	        return http.ListenAndServe(":8080", nil)
+//	    }),
+//	)
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/gontainer/v2"
+)
+
+// DefaultBuckets are the default upper bounds of duration histograms in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Labels of factory and entrypoint metrics.
+var (
+	factoryLabels    = [2]string{"type", "module"}
+	entrypointLabels = [2]string{"source", "module"}
+)
+
+// Collector collects container metrics from container events.
+type Collector struct {
+	mutex              sync.Mutex
+	spawnDuration      *histogramFamily
+	spawns             *counterFamily
+	spawnFailures      *counterFamily
+	closeDuration      *histogramFamily
+	closeFailures      *counterFamily
+	entrypointFailures *counterFamily
+	entrypointRestarts *counterFamily
+}
+
+// New returns a new collector using histogram buckets in seconds or DefaultBuckets.
+func New(buckets ...float64) *Collector {
+	// Prepare sorted histogram buckets.
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	// Prepare metric families.
+	return &Collector{
+		spawnDuration: newHistogramFamily(
+			"gontainer_factory_spawn_duration_seconds",
+			"Duration of factory function calls.",
+			factoryLabels, buckets,
+		),
+		spawns: newCounterFamily(
+			"gontainer_factory_spawns_total",
+			"Number of factory function calls.",
+			factoryLabels,
+		),
+		spawnFailures: newCounterFamily(
+			"gontainer_factory_spawn_failures_total",
+			"Number of factory function calls returning an error.",
+			factoryLabels,
+		),
+		closeDuration: newHistogramFamily(
+			"gontainer_factory_close_duration_seconds",
+			"Duration of factory close callback calls.",
+			factoryLabels, buckets,
+		),
+		closeFailures: newCounterFamily(
+			"gontainer_factory_close_failures_total",
+			"Number of factory close callback calls returning an error.",
+			factoryLabels,
+		),
+		entrypointFailures: newCounterFamily(
+			"gontainer_entrypoint_failures_total",
+			"Number of entrypoint calls returning an error.",
+			entrypointLabels,
+		),
+		entrypointRestarts: newCounterFamily(
+			"gontainer_entrypoint_restarts_total",
+			"Number of entrypoint restarts by restart policies.",
+			entrypointLabels,
+		),
+	}
+}
+
+// Option returns a container option observing events of the container.
+func (c *Collector) Option() gontainer.Option {
+	return gontainer.WithEventHandler(c.Observe)
+}
+
+// Observe updates metrics with the container event.
+func (c *Collector) Observe(event gontainer.Event) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Prepare series labels.
+	var typeName string
+	if event.Factory.Type != nil {
+		typeName = event.Factory.Type.String()
+	}
+	factoryKey := seriesKey{typeName, event.Factory.Module}
+	entrypointKey := seriesKey{event.Factory.Source, event.Factory.Module}
+
+	// Update metrics of the event.
+	switch event.Kind {
+	case gontainer.EventFactorySpawned:
+		c.spawnDuration.observe(factoryKey, event.Duration.Seconds())
+		c.spawns.inc(factoryKey)
+		if event.Err != nil {
+			c.spawnFailures.inc(factoryKey)
+		}
+	case gontainer.EventFactoryClosed:
+		c.closeDuration.observe(factoryKey, event.Duration.Seconds())
+		if event.Err != nil {
+			c.closeFailures.inc(factoryKey)
+		}
+	case gontainer.EventEntrypointFailed:
+		c.entrypointFailures.inc(entrypointKey)
+	case gontainer.EventEntrypointRestarting:
+		c.entrypointRestarts.inc(entrypointKey)
+	}
+}
+
+// WriteTo writes all metrics in the Prometheus text format.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Render all metric families.
+	writer := &countingWriter{writer: bufio.NewWriter(w)}
+	c.spawnDuration.write(writer)
+	c.spawns.write(writer)
+	c.spawnFailures.write(writer)
+	c.closeDuration.write(writer)
+	c.closeFailures.write(writer)
+	c.entrypointFailures.write(writer)
+	c.entrypointRestarts.write(writer)
+
+	// Flush buffered output.
+	if err := writer.writer.Flush(); err != nil {
+		return writer.count, err
+	}
+	return writer.count, nil
+}
+
+// Handler returns an HTTP handler serving metrics in the Prometheus text format.
+func (c *Collector) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = c.WriteTo(w)
+	})
+}
+
+// seriesKey is a pair of label values identifying a series.
+type seriesKey [2]string
+
+// counterFamily is a family of counter series.
+type counterFamily struct {
+	name   string
+	help   string
+	labels [2]string
+	values map[seriesKey]uint64
+}
+
+// newCounterFamily returns a new counter family.
+func newCounterFamily(name, help string, labels [2]string) *counterFamily {
+	return &counterFamily{name: name, help: help, labels: labels, values: map[seriesKey]uint64{}}
+}
+
+// inc increments the counter of the series.
+func (f *counterFamily) inc(key seriesKey) {
+	f.values[key]++
+}
+
+// write renders the counter family.
+func (f *counterFamily) write(w *countingWriter) {
+	writeHeader(w, f.name, f.help, "counter")
+	for _, key := range sortKeys(f.values) {
+		w.printf("%s{%s} %d\n", f.name, formatLabels(f.labels, key), f.values[key])
+	}
+}
+
+// histogramFamily is a family of histogram series.
+type histogramFamily struct {
+	name    string
+	help    string
+	labels  [2]string
+	buckets []float64
+	values  map[seriesKey]*histogram
+}
+
+// histogram is a single histogram series.
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// newHistogramFamily returns a new histogram family.
+func newHistogramFamily(name, help string, labels [2]string, buckets []float64) *histogramFamily {
+	return &histogramFamily{name: name, help: help, labels: labels, buckets: buckets, values: map[seriesKey]*histogram{}}
+}
+
+// observe adds the value to the histogram of the series.
+func (f *histogramFamily) observe(key seriesKey, value float64) {
+	// Prepare the series histogram.
+	series, ok := f.values[key]
+	if !ok {
+		series = &histogram{counts: make([]uint64, len(f.buckets))}
+		f.values[key] = series
+	}
+
+	// Count the value in the first matching bucket.
+	if index := sort.SearchFloat64s(f.buckets, value); index < len(f.buckets) {
+		series.counts[index]++
+	}
+	series.count++
+	series.sum += value
+}
+
+// write renders the histogram family with cumulative buckets.
+func (f *histogramFamily) write(w *countingWriter) {
+	writeHeader(w, f.name, f.help, "histogram")
+	for _, key := range sortKeys(f.values) {
+		series := f.values[key]
+		labels := formatLabels(f.labels, key)
+
+		// Render cumulative buckets.
+		var cumulative uint64
+		for index, bound := range f.buckets {
+			cumulative += series.counts[index]
+			w.printf("%s_bucket{%s,le=\"%s\"} %d\n", f.name, labels, formatFloat(bound), cumulative)
+		}
+		w.printf("%s_bucket{%s,le=\"+Inf\"} %d\n", f.name, labels, series.count)
+
+		// Render the sum and the count.
+		w.printf("%s_sum{%s} %s\n", f.name, labels, formatFloat(series.sum))
+		w.printf("%s_count{%s} %d\n", f.name, labels, series.count)
+	}
+}
+
+// countingWriter writes formatted output counting written bytes and keeping the first error.
+type countingWriter struct {
+	writer *bufio.Writer
+	count  int64
+	err    error
+}
+
+// printf writes formatted output unless a previous write failed.
+func (w *countingWriter) printf(format string, args ...any) {
+	if w.err != nil {
+		return
+	}
+	count, err := fmt.Fprintf(w.writer, format, args...)
+	w.count += int64(count)
+	w.err = err
+}
+
+// writeHeader renders the help and the type lines of a metric family.
+func writeHeader(w *countingWriter, name, help, metricType string) {
+	w.printf("# HELP %s %s\n", name, help)
+	w.printf("# TYPE %s %s\n", name, metricType)
+}
+
+// sortKeys returns series keys in the lexicographical order.
+func sortKeys[V any](values map[seriesKey]V) []seriesKey {
+	keys := make([]seriesKey, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
+
+// formatLabels renders label pairs of the series.
+func formatLabels(names [2]string, key seriesKey) string {
+	return names[0] + "=\"" + escapeLabel(key[0]) + "\"," + names[1] + "=\"" + escapeLabel(key[1]) + "\""
+}
+
+// labelEscaper escapes label values in the Prometheus text format.
+var labelEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n")
+
+// escapeLabel escapes the label value.
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+// formatFloat renders the float value in the Prometheus text format.
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,129 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/gontainer/v2"
+)
+
+// testService is a service of test factories.
+type testService struct{}
+
+// TestCollector tests metrics collected from a container run.
+func TestCollector(t *testing.T) {
+	collector := New()
+	attempts := 0
+	container, err := gontainer.New(
+		collector.Option(),
+		gontainer.NewFactory(func() (*testService, func() error) {
+			return &testService{}, func() error { return errors.New("close failed") }
+		}),
+		gontainer.NewEntrypoint(func(*testService) error {
+			attempts++
+			return errors.New("failed")
+		}, gontainer.WithRestart(gontainer.RestartPolicy{
+			Mode:        gontainer.RestartOnFailure,
+			MaxAttempts: 3,
+			Backoff:     time.Millisecond,
+		})),
+	)
+	equal(t, err, nil)
+	equal(t, container.Start() != nil, true)
+	equal(t, container.Close() != nil, true)
+	equal(t, attempts, 3)
+
+	// Serve collected metrics.
+	recorder := httptest.NewRecorder()
+	collector.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	equal(t, recorder.Code, http.StatusOK)
+	equal(t, recorder.Header().Get("Content-Type"), "text/plain; version=0.0.4; charset=utf-8")
+
+	// Validate rendered series.
+	body := recorder.Body.String()
+	for _, line := range []string{
+		`gontainer_factory_spawn_duration_seconds_count{type="*metrics.testService",module=""} 1`,
+		`gontainer_factory_spawns_total{type="*metrics.testService",module=""} 1`,
+		`gontainer_factory_close_duration_seconds_bucket{type="*metrics.testService",module="",le="+Inf"} 1`,
+		`gontainer_factory_close_failures_total{type="*metrics.testService",module=""} 1`,
+	} {
+		equal(t, strings.Contains(body, line+"\n"), true)
+	}
+	equal(t, strings.Contains(body, "gontainer_factory_spawn_failures_total{"), false)
+	equal(t, strings.Contains(body, `metrics_test.go:44",module=""} 3`+"\n"), true)
+	equal(t, strings.HasSuffix(body, `metrics_test.go:44",module=""} 2`+"\n"), true)
+}
+
+// TestCollectorFormat tests the text format of collected metrics.
+func TestCollectorFormat(t *testing.T) {
+	collector := New(1, 0.5)
+	collector.Observe(gontainer.Event{
+		Kind:     gontainer.EventFactorySpawned,
+		Factory:  gontainer.FactoryInfo{Type: reflect.TypeOf(""), Module: "app/\"db\""},
+		Duration: 750 * time.Millisecond,
+		Err:      errors.New("failed"),
+	})
+	collector.Observe(gontainer.Event{
+		Kind:    gontainer.EventEntrypointRestarting,
+		Factory: gontainer.FactoryInfo{Source: "main.go:10"},
+	})
+
+	// Render collected metrics.
+	var sb strings.Builder
+	count, err := collector.WriteTo(&sb)
+	equal(t, err, nil)
+	equal(t, count, int64(sb.Len()))
+	equal(t, sb.String(), ""+
+		"# HELP gontainer_factory_spawn_duration_seconds Duration of factory function calls.\n"+
+		"# TYPE gontainer_factory_spawn_duration_seconds histogram\n"+
+		`gontainer_factory_spawn_duration_seconds_bucket{type="string",module="app/\"db\"",le="0.5"} 0`+"\n"+
+		`gontainer_factory_spawn_duration_seconds_bucket{type="string",module="app/\"db\"",le="1"} 1`+"\n"+
+		`gontainer_factory_spawn_duration_seconds_bucket{type="string",module="app/\"db\"",le="+Inf"} 1`+"\n"+
+		`gontainer_factory_spawn_duration_seconds_sum{type="string",module="app/\"db\""} 0.75`+"\n"+
+		`gontainer_factory_spawn_duration_seconds_count{type="string",module="app/\"db\""} 1`+"\n"+
+		"# HELP gontainer_factory_spawns_total Number of factory function calls.\n"+
+		"# TYPE gontainer_factory_spawns_total counter\n"+
+		`gontainer_factory_spawns_total{type="string",module="app/\"db\""} 1`+"\n"+
+		"# HELP gontainer_factory_spawn_failures_total Number of factory function calls returning an error.\n"+
+		"# TYPE gontainer_factory_spawn_failures_total counter\n"+
+		`gontainer_factory_spawn_failures_total{type="string",module="app/\"db\""} 1`+"\n"+
+		"# HELP gontainer_factory_close_duration_seconds Duration of factory close callback calls.\n"+
+		"# TYPE gontainer_factory_close_duration_seconds histogram\n"+
+		"# HELP gontainer_factory_close_failures_total Number of factory close callback calls returning an error.\n"+
+		"# TYPE gontainer_factory_close_failures_total counter\n"+
+		"# HELP gontainer_entrypoint_failures_total Number of entrypoint calls returning an error.\n"+
+		"# TYPE gontainer_entrypoint_failures_total counter\n"+
+		"# HELP gontainer_entrypoint_restarts_total Number of entrypoint restarts by restart policies.\n"+
+		"# TYPE gontainer_entrypoint_restarts_total counter\n"+
+		`gontainer_entrypoint_restarts_total{source="main.go:10",module=""} 1`+"\n")
+}
+
+// equal fails the test when values are not deeply equal.
+func equal(t *testing.T, a, b any) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("equal failed: '%v' != '%v'", a, b)
+	}
+}
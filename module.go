@@ -0,0 +1,200 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// NewModule creates a new named module grouping the provided options.
+//
+// A module may contain factories, entrypoints, other modules and export declarations.
+// Traceback frames of factories registered in a module are prefixed with the module path,
+// e.g. "app/http: Factory for *Server".
+//
+// A module without export declarations exports all its services. A module with export
+// declarations exports only services assignable to the declared types.
+//
+// Example:
+//
+//	gontainer.NewModule("http",
+//	    gontainer.Export[*Server](),
+//	    gontainer.NewFactory(newConfig),
+//	    gontainer.NewFactory(newServer),
+//	)
+func NewModule(name string, options ...Option) *Module {
+	return &Module{
+		name:    name,
+		source:  getCallerSource(1),
+		options: slices.Clone(options),
+	}
+}
+
+// Module is a container option that registers a named group of options.
+type Module struct {
+	name    string
+	source  string
+	options []Option
+}
+
+// Name returns the name of the module.
+func (m *Module) Name() string {
+	return m.name
+}
+
+// Source returns the source package path of the module.
+func (m *Module) Source() string {
+	return m.source
+}
+
+// Options returns a copy of the module options.
+func (m *Module) Options() []Option {
+	return slices.Clone(m.options)
+}
+
+// apply applies the module option to the given registry.
+func (m *Module) apply(registry *registry) error {
+	// Enter the module scope.
+	registry.enterModule(m.name)
+	defer registry.leaveModule()
+
+	// Register module options in the registry.
+	for _, option := range m.options {
+		if err := option.apply(registry); err != nil {
+			return fmt.Errorf("failed to load Module[%s]: %w", m.name, err)
+		}
+	}
+
+	// Module registered.
+	return nil
+}
+
+// Export returns a module option declaring services assignable to T as exported.
+//
+// Example:
+//
+//	gontainer.NewModule("cache", gontainer.Export[Cache](), gontainer.NewFactory(newRedisCache))
+func Export[T any]() Option {
+	return exportOpt{typ: reflect.TypeOf((*T)(nil)).Elem()}
+}
+
+// exportOpt declares an exported type of the enclosing module.
+type exportOpt struct {
+	typ reflect.Type
+}
+
+// apply applies the export option to the given registry.
+func (o exportOpt) apply(registry *registry) error {
+	if registry.module == nil {
+		return fmt.Errorf("export outside of module: %s", o.typ)
+	}
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.module.exports = append(registry.module.exports, o.typ)
+	return nil
+}
+
+// WithPrivate returns an option that hides the factory service outside of its module.
+//
+// A private service is resolvable only by factories and entrypoints of the same
+// module and its nested modules, regardless of the module export declarations.
+// Private services of different modules do not conflict with each other.
+//
+// Example:
+//
+//	gontainer.NewModule("users", gontainer.NewFactory(newCache, gontainer.WithPrivate()))
+func WithPrivate() privateOpt {
+	return privateOpt{}
+}
+
+// privateOpt marks the factory as private.
+type privateOpt struct{}
+
+// applyFactory applies the option to the factory settings.
+func (o privateOpt) applyFactory(s *factorySettings) {
+	s.private = true
+}
+
+// module is the module internal representation.
+type module struct {
+	// Module name.
+	name string
+
+	// Module path from the root module.
+	path string
+
+	// Enclosing module or nil.
+	parent *module
+
+	// Exported service types.
+	exports []reflect.Type
+}
+
+// newModule creates a new module nested in the parent module.
+func newModule(name string, parent *module) *module {
+	path := name
+	if parent != nil {
+		path = parent.path + "/" + name
+	}
+	return &module{name: name, path: path, parent: parent}
+}
+
+// isExported returns true when the module exports services of the output type.
+func (m *module) isExported(outType reflect.Type) bool {
+	// Modules without export declarations export everything.
+	if len(m.exports) == 0 {
+		return true
+	}
+
+	// Check the output type against export declarations.
+	return slices.ContainsFunc(m.exports, func(exportType reflect.Type) bool {
+		return isTypeProvided(outType, exportType)
+	})
+}
+
+// isVisibleFrom returns true when the factory service is visible from the module scope.
+//
+// A service is visible when every module between the factory and the scope exports it,
+// private services are visible only within their own module.
+func (f *factory) isVisibleFrom(scope *module) bool {
+	for current := f.module; current != nil; current = current.parent {
+		if current.contains(scope) {
+			return true
+		}
+		if f.private && current == f.module {
+			return false
+		}
+		if !current.isExported(f.getOutType()) {
+			return false
+		}
+	}
+	return true
+}
+
+// contains returns true when the scope is the module itself or is nested into it.
+func (m *module) contains(scope *module) bool {
+	for current := scope; current != nil; current = current.parent {
+		if current == m {
+			return true
+		}
+	}
+	return false
+}
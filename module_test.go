@@ -0,0 +1,250 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestModuleRegistration tests registration of nested modules.
+func TestModuleRegistration(t *testing.T) {
+	option := NewModule("app",
+		NewFactory(func() string { return "string" }),
+		NewModule("http",
+			NewFactory(func(s string) int { return len(s) }),
+			NewEntrypoint(func(int) {}),
+		),
+		NewService(true),
+	)
+	equal(t, option.Name(), "app")
+	equal(t, strings.Contains(option.Source(), "module_test.go:"), true)
+	equal(t, len(option.Options()), 3)
+
+	registry := &registry{}
+	equal(t, option.apply(registry), nil)
+	equal(t, registry.module, (*module)(nil))
+
+	equal(t, len(registry.factories), 3)
+	equal(t, registry.factories[0].module.path, "app")
+	equal(t, registry.factories[1].module.path, "app/http")
+	equal(t, registry.factories[1].module.parent, registry.factories[0].module)
+	equal(t, registry.factories[2].module.path, "app")
+
+	equal(t, len(registry.entrypoints), 1)
+	equal(t, registry.entrypoints[0].module.path, "app/http")
+}
+
+// TestModuleRegistrationError tests errors of module registration.
+func TestModuleRegistrationError(t *testing.T) {
+	err := Run(NewModule("app", NewModule("http", NewFactory(42))))
+	equal(t, err.Error(), "failed to load Module[app]: failed to load Module[http]: invalid type: int")
+
+	err = Run(Export[string]())
+	equal(t, err.Error(), "export outside of module: string")
+}
+
+// TestModuleTraceback tests module paths in tracebacks.
+func TestModuleTraceback(t *testing.T) {
+	err := Run(
+		NewModule("app",
+			NewModule("db",
+				NewFactory(func() (*testFmtLeaf, error) {
+					return nil, errors.New("boom")
+				}),
+			),
+			NewEntrypoint(func(*testFmtLeaf) {}),
+		),
+	)
+	equal(t, normalizeSourceLines(err.Error()), ""+
+		"boom\n\n"+
+		"Traceback:\n"+
+		"  app/db: Factory for *gontainer.testFmtLeaf\n"+
+		"  app: Entrypoint")
+}
+
+// TestModuleExports tests visibility of module services.
+func TestModuleExports(t *testing.T) {
+	registry := &registry{}
+	equal(t, NewModule("app",
+		Export[interface{ Do2() }](),
+		NewService(&testService1{}),
+		NewService(&testService3{}),
+		NewModule("http",
+			Export[*testService2](),
+			Export[*testService4](),
+			NewService(&testService2{}),
+			NewService(&testService4{}),
+			NewService(true),
+		),
+		NewModule("db",
+			NewService("string"),
+		),
+	).apply(registry), nil)
+
+	app := registry.factories[0].module
+	http := registry.factories[2].module
+	db := registry.factories[5].module
+
+	tests := []struct {
+		name    string
+		index   int
+		visible []*module
+		hidden  []*module
+	}{
+		{name: "ExportedFromApp", index: 0, visible: []*module{nil, app, http, db}},
+		{name: "PrivateInApp", index: 1, visible: []*module{app, http, db}, hidden: []*module{nil}},
+		{name: "ExportedFromHTTPAndApp", index: 2, visible: []*module{nil, app, http, db}},
+		{name: "ExportedFromHTTPOnly", index: 3, visible: []*module{app, http, db}, hidden: []*module{nil}},
+		{name: "PrivateInHTTP", index: 4, visible: []*module{http}, hidden: []*module{nil, app, db}},
+		{name: "NotExportedFromApp", index: 5, visible: []*module{app, http, db}, hidden: []*module{nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fact := registry.factories[tt.index]
+			for _, scope := range tt.visible {
+				equal(t, fact.isVisibleFrom(scope), true)
+			}
+			for _, scope := range tt.hidden {
+				equal(t, fact.isVisibleFrom(scope), false)
+			}
+		})
+	}
+}
+
+// TestModuleInspector tests module information in the inspector.
+func TestModuleInspector(t *testing.T) {
+	var infos []FactoryInfo
+	equal(t, Run(
+		NewModule("app",
+			Export[string](),
+			NewService("string"),
+			NewService(123),
+			NewEntrypoint(func(inspector *Inspector) {
+				infos = withoutBuiltins(inspector.Factories())
+				infos = append(infos, inspector.Entrypoints()...)
+			}),
+		),
+		NewService(true),
+	), nil)
+
+	equal(t, len(infos), 4)
+	equal(t, infos[0].Module, "app")
+	equal(t, infos[0].Private, false)
+	equal(t, infos[1].Module, "app")
+	equal(t, infos[1].Private, true)
+	equal(t, infos[2].Module, "")
+	equal(t, infos[2].Private, false)
+	equal(t, infos[3].Module, "app")
+	equal(t, infos[3].Private, false)
+}
+
+// TestModulePrivateServices tests resolution of private services.
+func TestModulePrivateServices(t *testing.T) {
+	type cache struct{ owner string }
+	type usersRepo struct{ cache *cache }
+	type ordersRepo struct{ cache *cache }
+
+	var users *usersRepo
+	var orders *ordersRepo
+	var optional Optional[*cache]
+
+	equal(t, Run(
+		NewModule("users",
+			NewFactory(func() *cache { return &cache{owner: "users"} }, WithPrivate()),
+			NewFactory(func(c *cache) *usersRepo { return &usersRepo{cache: c} }),
+		),
+		NewModule("orders",
+			NewFactory(func() *cache { return &cache{owner: "orders"} }, WithPrivate()),
+			NewModule("repo",
+				NewFactory(func(c *cache) *ordersRepo { return &ordersRepo{cache: c} }),
+			),
+		),
+		NewEntrypoint(func(u *usersRepo, o *ordersRepo, c Optional[*cache]) {
+			users, orders, optional = u, o, c
+		}),
+	), nil)
+
+	equal(t, users.cache.owner, "users")
+	equal(t, orders.cache.owner, "orders")
+	equal(t, optional.Ok(), false)
+}
+
+// TestModulePrivateErrors tests errors of private services.
+func TestModulePrivateErrors(t *testing.T) {
+	t.Run("ValidationError", func(t *testing.T) {
+		err := Run(
+			NewModule("app",
+				NewModule("db", NewService(&testFmtLeaf{}, WithPrivate())),
+				NewEntrypoint(func(*testFmtLeaf) {}),
+			),
+		)
+		equal(t, errors.Is(err, ErrDependencyPrivate), true)
+		equal(t, errors.Is(err, ErrDependencyNotResolved), true)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"dependency is private: *gontainer.testFmtLeaf in module app/db\n\n"+
+			"Traceback:\n"+
+			"  app: Entrypoint")
+	})
+
+	t.Run("NotExportedValidationError", func(t *testing.T) {
+		err := Run(
+			NewModule("db", Export[string](), NewService(&testFmtLeaf{})),
+			NewEntrypoint(func(*testFmtLeaf) {}),
+		)
+		equal(t, errors.Is(err, ErrDependencyPrivate), true)
+	})
+
+	t.Run("ResolverError", func(t *testing.T) {
+		var resolveErr error
+		equal(t, Run(
+			NewModule("db", NewService(&testFmtLeaf{}, WithPrivate())),
+			NewEntrypoint(func(resolver *Resolver) {
+				_, resolveErr = Resolve[*testFmtLeaf](resolver)
+			}),
+		), nil)
+		equal(t, errors.Is(resolveErr, ErrDependencyPrivate), true)
+		equal(t, normalizeSourceLines(resolveErr.Error()), ""+
+			"dependency is private: *gontainer.testFmtLeaf in module db\n\n"+
+			"Traceback:")
+	})
+
+	t.Run("PrivateOutsideOfModule", func(t *testing.T) {
+		err := Run(NewService("string", WithPrivate()))
+		equal(t, err.Error(), "failed to load Service[string]: private factory outside of module")
+	})
+
+	t.Run("PrivateConflictsWithinModule", func(t *testing.T) {
+		err := Run(
+			NewService("public"),
+			NewModule("app", NewService("private", WithPrivate())),
+			NewEntrypoint(func(string) {}),
+		)
+		equal(t, errors.Is(err, ErrFactoryTypeDuplicated), true)
+
+		unwrap, ok := err.(interface{ Unwrap() []error })
+		equal(t, ok, true)
+		errs := unwrap.Unwrap()
+		equal(t, len(errs), 1)
+		equal(t, normalizeSourceLines(errs[0].Error()), ""+
+			"factory type duplicated: string\n\n"+
+			"Traceback:\n"+
+			"  app: Factory for string")
+	})
+}
@@ -0,0 +1,206 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// WithOrder returns an option that sets the position of the factory service
+// within Multiple and Tagged dependencies.
+//
+// Services are sorted by ascending order value; services with equal order
+// values keep the registration order. The default order value is zero.
+//
+// Example:
+//
+//	gontainer.NewFactory(newRecoveryMiddleware, gontainer.WithOrder(-100))
+func WithOrder(order int) orderOpt {
+	return orderOpt{order: order}
+}
+
+// orderOpt sets the factory order value.
+type orderOpt struct {
+	order int
+}
+
+// applyFactory applies the option to the factory settings.
+func (o orderOpt) applyFactory(s *factorySettings) {
+	s.order = o.order
+}
+
+// WithBefore returns an option that places the factory service before
+// services assignable to T within Multiple and Tagged dependencies.
+//
+// Before and after constraints take precedence over order values.
+//
+// Example:
+//
+//	gontainer.NewFactory(newAuthMiddleware, gontainer.WithBefore[*LoggingMiddleware]())
+func WithBefore[T any]() beforeOpt {
+	return beforeOpt{typ: reflect.TypeOf((*T)(nil)).Elem()}
+}
+
+// beforeOpt places the factory before services of the type.
+type beforeOpt struct {
+	typ reflect.Type
+}
+
+// applyFactory applies the option to the factory settings.
+func (o beforeOpt) applyFactory(s *factorySettings) {
+	s.before = append(s.before, o.typ)
+}
+
+// WithAfter returns an option that places the factory service after
+// services assignable to T within Multiple and Tagged dependencies.
+//
+// Before and after constraints take precedence over order values.
+//
+// Example:
+//
+//	gontainer.NewFactory(newLoggingMiddleware, gontainer.WithAfter[*AuthMiddleware]())
+func WithAfter[T any]() afterOpt {
+	return afterOpt{typ: reflect.TypeOf((*T)(nil)).Elem()}
+}
+
+// afterOpt places the factory after services of the type.
+type afterOpt struct {
+	typ reflect.Type
+}
+
+// applyFactory applies the option to the factory settings.
+func (o afterOpt) applyFactory(s *factorySettings) {
+	s.after = append(s.after, o.typ)
+}
+
+// orderFactories returns factories sorted by order values and before/after constraints.
+//
+// Factories are sorted by order values keeping the registration order, then
+// each factory is placed right after all factories it is constrained to follow.
+func orderFactories(factories []*factory) []*factory {
+	// Sort factories by order values keeping the registration order.
+	sorted := slices.Clone(factories)
+	slices.SortStableFunc(sorted, func(a, b *factory) int {
+		return cmp.Compare(a.order, b.order)
+	})
+
+	// Prepare placement state.
+	results := make([]*factory, 0, len(sorted))
+	placed := make(map[*factory]bool, len(sorted))
+	visiting := make(map[*factory]bool, len(sorted))
+
+	// Place the factory after all its predecessors.
+	var place func(fact *factory)
+	place = func(fact *factory) {
+		// Contradictory constraints are reported by validation.
+		if placed[fact] || visiting[fact] {
+			return
+		}
+
+		// Place predecessors first.
+		visiting[fact] = true
+		for _, other := range sorted {
+			if isOrderedBefore(other, fact) {
+				place(other)
+			}
+		}
+
+		// Place the factory.
+		placed[fact] = true
+		results = append(results, fact)
+	}
+
+	// Place all factories.
+	for _, fact := range sorted {
+		place(fact)
+	}
+
+	// Return ordered factories.
+	return results
+}
+
+// isOrderedBefore returns true when constraints require factory a to precede factory b.
+func isOrderedBefore(a, b *factory) bool {
+	// Factories are never ordered against themselves.
+	if a == b {
+		return false
+	}
+
+	// Factory a declares to precede b.
+	for _, typ := range a.before {
+		if isTypeProvided(b.getOutType(), typ) {
+			return true
+		}
+	}
+
+	// Factory b declares to follow a.
+	for _, typ := range b.after {
+		if isTypeProvided(a.getOutType(), typ) {
+			return true
+		}
+	}
+
+	// No constraints between factories.
+	return false
+}
+
+// findOrderCycles returns factories with order constraints leading back to them, in the given order.
+//
+// Order edges are collected once from factories declaring constraints, then strongly
+// connected components are found in a single pass over the whole order graph.
+func findOrderCycles(factories []*factory) []*factory {
+	// Collect order edges of factories declaring constraints.
+	edges := map[*factory][]*factory{}
+	for _, fact := range factories {
+		if len(fact.before) == 0 && len(fact.after) == 0 {
+			continue
+		}
+		for _, other := range factories {
+			if isOrderedBefore(fact, other) {
+				edges[fact] = append(edges[fact], other)
+			}
+			if isOrderedBefore(other, fact) {
+				edges[other] = append(edges[other], fact)
+			}
+		}
+	}
+
+	// Factories are never ordered against themselves, so only components of many factories are cycles.
+	cyclic := map[*factory]bool{}
+	if len(edges) > 0 {
+		for _, component := range findComponents(factories, edges) {
+			if len(component) < 2 {
+				continue
+			}
+			for _, fact := range component {
+				cyclic[fact] = true
+			}
+		}
+	}
+
+	// Return factories on cycles in the given order.
+	var results []*factory
+	for _, fact := range factories {
+		if cyclic[fact] {
+			results = append(results, fact)
+		}
+	}
+	return results
+}
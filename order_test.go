@@ -0,0 +1,159 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestMultipleOrder tests ordering of multiple dependencies.
+func TestMultipleOrder(t *testing.T) {
+	svc1 := &testService1{}
+	svc2 := &testService2{}
+	svc3 := &testService3{}
+	svc4 := &testService4{}
+
+	tests := []struct {
+		name    string
+		options []Option
+		want    Multiple[interface{ Do1() }]
+	}{
+		{
+			name: "RegistrationOrder",
+			options: []Option{
+				NewService(svc1), NewService(svc2), NewService(svc3), NewService(svc4),
+			},
+			want: Multiple[interface{ Do1() }]{svc1, svc2, svc3, svc4},
+		},
+		{
+			name: "OrderValues",
+			options: []Option{
+				NewService(svc1, WithOrder(10)),
+				NewService(svc2),
+				NewService(svc3, WithOrder(-10)),
+				NewService(svc4, WithOrder(10)),
+			},
+			want: Multiple[interface{ Do1() }]{svc3, svc2, svc1, svc4},
+		},
+		{
+			name: "ExtremeOrderValues",
+			options: []Option{
+				NewService(svc1, WithOrder(math.MaxInt)),
+				NewService(svc2, WithOrder(math.MinInt)),
+				NewService(svc3),
+				NewService(svc4, WithOrder(math.MinInt)),
+			},
+			want: Multiple[interface{ Do1() }]{svc2, svc4, svc3, svc1},
+		},
+		{
+			name: "BeforeConstraint",
+			options: []Option{
+				NewService(svc1), NewService(svc2),
+				NewService(svc3, WithBefore[*testService1]()),
+				NewService(svc4),
+			},
+			want: Multiple[interface{ Do1() }]{svc3, svc1, svc2, svc4},
+		},
+		{
+			name: "AfterConstraint",
+			options: []Option{
+				NewService(svc1, WithAfter[*testService4]()),
+				NewService(svc2), NewService(svc3), NewService(svc4),
+			},
+			want: Multiple[interface{ Do1() }]{svc4, svc1, svc2, svc3},
+		},
+		{
+			name: "ConstraintsOverrideOrderValues",
+			options: []Option{
+				NewService(svc1, WithOrder(-10), WithAfter[interface{ Do2() }]()),
+				NewService(svc2, WithOrder(10)),
+				NewService(svc3),
+				NewService(svc4),
+			},
+			want: Multiple[interface{ Do1() }]{svc2, svc1, svc3, svc4},
+		},
+		{
+			name: "ConstraintsOnAbsentTypes",
+			options: []Option{
+				NewService(svc1, WithAfter[*testService5]()),
+				NewService(svc2, WithBefore[string]()),
+				NewService(svc3), NewService(svc4),
+			},
+			want: Multiple[interface{ Do1() }]{svc1, svc2, svc3, svc4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var multiple Multiple[interface{ Do1() }]
+			options := append(tt.options, NewEntrypoint(func(m Multiple[interface{ Do1() }]) {
+				multiple = m
+			}))
+			equal(t, Run(options...), nil)
+			equal(t, multiple, tt.want)
+		})
+	}
+}
+
+// TestTaggedOrder tests ordering of tagged dependencies.
+func TestTaggedOrder(t *testing.T) {
+	type tag struct{}
+
+	svc1 := &testService1{}
+	svc2 := &testService2{}
+	svc3 := &testService3{}
+
+	var tagged Tagged[interface{ Do1() }, tag]
+	equal(t, Run(
+		NewService(svc1, WithAnnotation(tag{}), WithOrder(1)),
+		NewService(svc2, WithAnnotation(tag{})),
+		NewService(svc3, WithBefore[*testService2]()),
+		NewEntrypoint(func(g Tagged[interface{ Do1() }, tag]) { tagged = g }),
+	), nil)
+	equal(t, tagged, Tagged[interface{ Do1() }, tag]{svc2, svc1})
+}
+
+// TestContradictoryOrder tests validation of order constraints.
+func TestContradictoryOrder(t *testing.T) {
+	err := Run(
+		NewService(&testService1{}, WithBefore[*testService2]()),
+		NewService(&testService2{}, WithBefore[*testService3]()),
+		NewService(&testService3{}, WithBefore[*testService1]()),
+		NewService(&testService4{}, WithAfter[*testService1]()),
+		NewEntrypoint(func(Multiple[interface{ Do1() }]) {}),
+	)
+	equal(t, errors.Is(err, ErrContradictoryOrder), true)
+
+	unwrap, ok := err.(interface{ Unwrap() []error })
+	equal(t, ok, true)
+	errs := unwrap.Unwrap()
+	equal(t, len(errs), 3)
+	equal(t, normalizeSourceLines(errs[0].Error()), ""+
+		"contradictory order\n\n"+
+		"Traceback:\n"+
+		"  Factory for *gontainer.testService1")
+	equal(t, normalizeSourceLines(errs[1].Error()), ""+
+		"contradictory order\n\n"+
+		"Traceback:\n"+
+		"  Factory for *gontainer.testService2")
+	equal(t, normalizeSourceLines(errs[2].Error()), ""+
+		"contradictory order\n\n"+
+		"Traceback:\n"+
+		"  Factory for *gontainer.testService3")
+}
@@ -0,0 +1,169 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"reflect"
+	"sync"
+)
+
+// WithParallelInit returns a container option that resolves independent dependencies concurrently.
+//
+// Input dependencies of a factory or entrypoint are resolved in separate goroutines,
+// so independent dependency subtrees, e.g. database, cache and object store clients,
+// are spawned concurrently. Every factory is still spawned exactly once.
+//
+// The limit is the maximum number of goroutines resolving dependencies at the same time,
+// zero or a negative value means no limit. When the limit is reached, the remaining
+// dependencies are resolved in the calling goroutine.
+//
+// Resolution errors are reported in the order of the factory input parameters.
+//
+// Example:
+//
+//	gontainer.Run(gontainer.WithParallelInit(8), app.Options()...)
+func WithParallelInit(limit int) Option {
+	return parallelInitOpt{limit: limit}
+}
+
+// parallelInitOpt enables the parallel initialization.
+type parallelInitOpt struct {
+	limit int
+}
+
+// apply applies the parallel initialization option to the given registry.
+func (o parallelInitOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.parallel = true
+	registry.workers = nil
+	if o.limit > 0 {
+		registry.workers = make(chan struct{}, o.limit)
+	}
+	return nil
+}
+
+// resolveInputs resolves input dependencies of the factory.
+func (r *registry) resolveInputs(fact *factory) ([]reflect.Value, error) {
+	// Resolve a single dependency or all dependencies sequentially.
+	if !r.parallel || len(fact.inTypes) < 2 {
+		inValues := make([]reflect.Value, 0, len(fact.inTypes))
+		for _, inType := range fact.inTypes {
+			// Resolve factory input dependency.
+			inValue, err := r.resolveService(inType, fact)
+			if err != nil {
+				return nil, err
+			}
+
+			// Append resolved input value.
+			inValues = append(inValues, inValue)
+		}
+		return inValues, nil
+	}
+
+	// Resolve dependencies concurrently.
+	return r.resolveInputsParallel(fact)
+}
+
+// resolveInputsParallel resolves input dependencies of the factory concurrently.
+func (r *registry) resolveInputsParallel(fact *factory) ([]reflect.Value, error) {
+	// Prepare results indexed by the input parameters.
+	inValues := make([]reflect.Value, len(fact.inTypes))
+	inErrors := make([]error, len(fact.inTypes))
+	inPanics := make([]any, len(fact.inTypes))
+
+	// Resolve a single dependency recovering the panic.
+	resolve := func(index int) {
+		defer func() {
+			inPanics[index] = recover()
+		}()
+		inValues[index], inErrors[index] = r.resolveService(fact.inTypes[index], fact)
+	}
+
+	// Start resolving dependencies in goroutines while workers are available.
+	var wg sync.WaitGroup
+	var inline []int
+	for index := range fact.inTypes {
+		if !r.acquireWorker() {
+			inline = append(inline, index)
+			continue
+		}
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			defer r.releaseWorker()
+			resolve(index)
+		}(index)
+	}
+
+	// Resolve remaining dependencies in the calling goroutine.
+	for _, index := range inline {
+		resolve(index)
+	}
+
+	// Wait for all dependencies.
+	wg.Wait()
+
+	// Propagate panics to the calling goroutine.
+	for _, value := range inPanics {
+		if value != nil {
+			panic(value)
+		}
+	}
+
+	// Collect errors in the order of the input parameters.
+	var errs errorGroup
+	for _, err := range inErrors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Return a single error as is.
+	switch len(errs) {
+	case 0:
+		return inValues, nil
+	case 1:
+		return nil, errs[0]
+	default:
+		return nil, errs
+	}
+}
+
+// acquireWorker returns true when a goroutine may be started to resolve a dependency.
+func (r *registry) acquireWorker() bool {
+	if r.workers == nil {
+		return true
+	}
+	select {
+	case r.workers <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// releaseWorker releases the worker acquired by acquireWorker.
+func (r *registry) releaseWorker() {
+	if r.workers != nil {
+		<-r.workers
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestParallelInit tests concurrent resolution of independent dependencies.
+func TestParallelInit(t *testing.T) {
+	// Every factory waits for the others to be started.
+	var started sync.WaitGroup
+	started.Add(3)
+	wait := func() bool {
+		started.Done()
+		done := make(chan struct{})
+		go func() {
+			started.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return true
+		case <-time.After(5 * time.Second):
+			return false
+		}
+	}
+
+	// Run container.
+	var spawns atomic.Int32
+	equal(t, Run(
+		WithParallelInit(0),
+		NewFactory(func() *testFmtLeaf {
+			spawns.Add(1)
+			return &testFmtLeaf{}
+		}),
+		NewFactory(func(*testFmtLeaf) *testFmtRootA { return &testFmtRootA{} }),
+		NewFactory(func(*testFmtLeaf) *testFmtRootB { return &testFmtRootB{} }),
+		NewEntrypoint(func(*testFmtRootA, *testFmtRootB) {}),
+		NewFactory(func() float32 { equal(t, wait(), true); return 1 }),
+		NewFactory(func() float64 { equal(t, wait(), true); return 2 }),
+		NewFactory(func() uint { equal(t, wait(), true); return 3 }),
+		NewEntrypoint(func(float32, float64, uint) {}),
+	), nil)
+	equal(t, spawns.Load(), int32(1))
+}
+
+// TestParallelInitLimit tests the concurrency limit of the parallel initialization.
+func TestParallelInitLimit(t *testing.T) {
+	var active, peak atomic.Int32
+	track := func() {
+		current := active.Add(1)
+		for {
+			previous := peak.Load()
+			if current <= previous || peak.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		active.Add(-1)
+	}
+
+	// One worker goroutine and the calling goroutine.
+	equal(t, Run(
+		WithParallelInit(1),
+		NewFactory(func() int { track(); return 1 }),
+		NewFactory(func() int8 { track(); return 2 }),
+		NewFactory(func() int16 { track(); return 3 }),
+		NewFactory(func() int32 { track(); return 4 }),
+		NewEntrypoint(func(int, int8, int16, int32) {}),
+	), nil)
+	equal(t, peak.Load() <= 2, true)
+}
+
+// TestParallelInitErrors tests deterministic aggregation of errors of parallel dependencies.
+func TestParallelInitErrors(t *testing.T) {
+	for index := 0; index < 10; index++ {
+		err := Run(
+			WithParallelInit(0),
+			NewFactory(func() (*testFmtLeaf, error) { return nil, errors.New("leaf boom") }),
+			NewFactory(func() *testFmtMid { return &testFmtMid{} }),
+			NewFactory(func() (*testFmtRootA, error) { return nil, errors.New("root boom") }),
+			NewEntrypoint(func(*testFmtRootA, *testFmtMid, *testFmtLeaf) {}),
+		)
+		equal(t, errors.Is(err, ErrFactoryReturnedError), true)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"root boom\n\n"+
+			"Traceback:\n"+
+			"  Factory for *gontainer.testFmtRootA\n"+
+			"  Entrypoint\n\n"+
+			"leaf boom\n\n"+
+			"Traceback:\n"+
+			"  Factory for *gontainer.testFmtLeaf\n"+
+			"  Entrypoint")
+	}
+}
+
+// TestParallelInitPanic tests propagation of factory panics to the calling goroutine.
+func TestParallelInitPanic(t *testing.T) {
+	equal(t, recovers(func() {
+		_ = Run(
+			WithParallelInit(0),
+			NewFactory(func() int { panic("boom") }),
+			NewFactory(func() string { return "" }),
+			NewEntrypoint(func(int, string) {}),
+		)
+	}), true)
+}
@@ -0,0 +1,88 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"slices"
+)
+
+// WithProfile returns an option that registers a Factory or Entrypoint only in the profile.
+//
+// A factory with profiles is registered when any of its profiles is activated with
+// WithActiveProfiles, factories without profiles are always registered.
+//
+// Example:
+//
+//	gontainer.NewFactory(newFakeMailer, gontainer.WithProfile("dev"), gontainer.WithProfile("test"))
+//	gontainer.NewFactory(newSMTPMailer, gontainer.WithProfile("prod"))
+func WithProfile(profile string) profileOpt {
+	return profileOpt{profile: profile}
+}
+
+// profileOpt is a profile of a Factory or Entrypoint.
+type profileOpt struct {
+	profile string
+}
+
+// applyFactory applies the option to the factory settings.
+func (o profileOpt) applyFactory(s *factorySettings) {
+	s.profiles = append(s.profiles, o.profile)
+}
+
+// applyEntrypoint applies the option to the entrypoint settings.
+func (o profileOpt) applyEntrypoint(s *entrypointSettings) {
+	s.profiles = append(s.profiles, o.profile)
+}
+
+// WithActiveProfiles returns a container option that activates the profiles.
+//
+// Profiles are applied by Run after all options are registered,
+// so the option may be passed in any position.
+//
+// Example:
+//
+//	gontainer.Run(gontainer.WithActiveProfiles(os.Getenv("APP_PROFILE")), factories...)
+func WithActiveProfiles(profiles ...string) Option {
+	return activeProfilesOpt{profiles: slices.Clone(profiles)}
+}
+
+// activeProfilesOpt activates the profiles.
+type activeProfilesOpt struct {
+	profiles []string
+}
+
+// apply applies the active profiles option to the given registry.
+func (o activeProfilesOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.profiles = append(registry.profiles, o.profiles...)
+	return nil
+}
+
+// isProfileActive returns true when the factory has no profiles or any of its profiles is active.
+func (f *factory) isProfileActive(activeProfiles []string) bool {
+	if len(f.profiles) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(f.profiles, func(profile string) bool {
+		return slices.Contains(activeProfiles, profile)
+	})
+}
@@ -0,0 +1,148 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestProfiles tests registration of factories by profiles.
+func TestProfiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles []Option
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "DevProfile",
+			profiles: []Option{WithActiveProfiles("dev")},
+			want:     "fake",
+		},
+		{
+			name:     "TestProfile",
+			profiles: []Option{WithActiveProfiles("test")},
+			want:     "fake",
+		},
+		{
+			name:     "ProdProfile",
+			profiles: []Option{WithActiveProfiles("prod")},
+			want:     "smtp",
+		},
+		{
+			name:     "AccumulatedProfiles",
+			profiles: []Option{WithActiveProfiles("staging"), WithActiveProfiles("prod")},
+			want:     "smtp",
+		},
+		{
+			name:     "UnsatisfiedCondition",
+			profiles: []Option{When(isTestConditionFalse, WithActiveProfiles("prod")), WithActiveProfiles("dev")},
+			want:     "fake",
+		},
+		{
+			name:     "NoActiveProfiles",
+			profiles: nil,
+			wantErr:  ErrDependencyInactive,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mailer string
+			options := []Option{
+				NewFactory(func() string { return "fake" }, WithProfile("dev"), WithProfile("test")),
+				NewFactory(func() string { return "smtp" }, WithProfile("prod")),
+				NewEntrypoint(func(m string) { mailer = m }),
+			}
+			err := Run(append(options, tt.profiles...)...)
+			if tt.wantErr != nil {
+				equal(t, errors.Is(err, tt.wantErr), true)
+				return
+			}
+			equal(t, err, nil)
+			equal(t, mailer, tt.want)
+		})
+	}
+}
+
+// TestProfileEntrypoints tests registration of entrypoints by profiles.
+func TestProfileEntrypoints(t *testing.T) {
+	invoked := ""
+	equal(t, Run(
+		WithActiveProfiles("migrate"),
+		NewEntrypoint(func() { invoked += "serve" }, WithProfile("serve")),
+		NewEntrypoint(func() { invoked += "migrate" }, WithProfile("migrate")),
+	), nil)
+	equal(t, invoked, "migrate")
+
+	err := Run(
+		NewEntrypoint(func() {}, WithProfile("serve")),
+	)
+	equal(t, errors.Is(err, ErrNoEntrypointsProvided), true)
+}
+
+// TestProfileErrors tests validation errors of inactive profiles.
+func TestProfileErrors(t *testing.T) {
+	t.Run("InactiveProfile", func(t *testing.T) {
+		err := Run(
+			WithActiveProfiles("dev"),
+			NewFactory(func() *testFmtLeaf { return &testFmtLeaf{} }, WithProfile("prod"), WithProfile("staging")),
+			NewEntrypoint(func(*testFmtLeaf) {}),
+		)
+		equal(t, errors.Is(err, ErrDependencyInactive), true)
+		equal(t, errors.Is(err, ErrDependencyNotResolved), true)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"dependency is inactive: *gontainer.testFmtLeaf in profile prod, staging\n\n"+
+			"Traceback:\n"+
+			"  Entrypoint")
+	})
+
+	t.Run("UnsatisfiedCondition", func(t *testing.T) {
+		err := Run(
+			When(isTestConditionFalse, NewService(&testFmtLeaf{})),
+			NewEntrypoint(func(*testFmtLeaf) {}),
+		)
+		equal(t, errors.Is(err, ErrDependencyInactive), true)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"dependency is inactive: *gontainer.testFmtLeaf by condition When[isTestConditionFalse]\n\n"+
+			"Traceback:\n"+
+			"  Entrypoint")
+	})
+}
+
+// TestProfileInspector tests profile information in the inspector.
+func TestProfileInspector(t *testing.T) {
+	var active, inactive []FactoryInfo
+	equal(t, Run(
+		WithActiveProfiles("dev"),
+		NewService("fake", WithProfile("dev")),
+		NewService("smtp", WithProfile("prod")),
+		NewEntrypoint(func(inspector *Inspector) {
+			active = withoutBuiltins(inspector.Factories())
+			inactive = inspector.Inactive()
+		}),
+	), nil)
+
+	equal(t, len(active), 1)
+	equal(t, active[0].Profiles, []string{"dev"})
+	equal(t, active[0].Active, true)
+
+	equal(t, len(inactive), 1)
+	equal(t, inactive[0].Profiles, []string{"prod"})
+	equal(t, inactive[0].Active, false)
+}
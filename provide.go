@@ -0,0 +1,193 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"reflect"
+)
+
+// Provide0 creates a new service factory from a typed function without dependencies.
+//
+// Provide functions are reflection-free alternatives to NewFactory: the function signature
+// is checked by the compiler, and the function is invoked directly instead of via reflection.
+// Factories created by Provide functions are fully interoperable with NewFactory ones.
+//
+// Example:
+//
+//	gontainer.Provide0(newConfig)
+//	gontainer.Provide1(func(cfg *Config) *Database { ... })
+//	gontainer.Provide2Err(func(cfg *Config, db *Database) (*Handler, error) { ... })
+func Provide0[T any](function func() T, opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func([]reflect.Value) (T, func() error, error) {
+		return function(), nil, nil
+	}, opts)
+}
+
+// Provide0Err creates a new service factory from a typed function returning an error.
+func Provide0Err[T any](function func() (T, error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func([]reflect.Value) (T, func() error, error) {
+		service, err := function()
+		return service, nil, err
+	}, opts)
+}
+
+// Provide0Close creates a new service factory from a typed function returning a close callback.
+func Provide0Close[T any](function func() (T, func() error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func([]reflect.Value) (T, func() error, error) {
+		service, closeFn := function()
+		return service, closeFn, nil
+	}, opts)
+}
+
+// Provide0CloseErr creates a new service factory from a typed function returning a close callback and an error.
+func Provide0CloseErr[T any](function func() (T, func() error, error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func([]reflect.Value) (T, func() error, error) {
+		return function()
+	}, opts)
+}
+
+// Provide1 creates a new service factory from a typed function with one dependency.
+func Provide1[A, T any](function func(A) T, opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		return function(typedArg[A](in[0])), nil, nil
+	}, opts)
+}
+
+// Provide1Err creates a new service factory from a typed function with one dependency returning an error.
+func Provide1Err[A, T any](function func(A) (T, error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		service, err := function(typedArg[A](in[0]))
+		return service, nil, err
+	}, opts)
+}
+
+// Provide1Close creates a new service factory from a typed function with one dependency
+// returning a close callback.
+func Provide1Close[A, T any](function func(A) (T, func() error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		service, closeFn := function(typedArg[A](in[0]))
+		return service, closeFn, nil
+	}, opts)
+}
+
+// Provide1CloseErr creates a new service factory from a typed function with one dependency
+// returning a close callback and an error.
+func Provide1CloseErr[A, T any](function func(A) (T, func() error, error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		return function(typedArg[A](in[0]))
+	}, opts)
+}
+
+// Provide2 creates a new service factory from a typed function with two dependencies.
+func Provide2[A, B, T any](function func(A, B) T, opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		return function(typedArg[A](in[0]), typedArg[B](in[1])), nil, nil
+	}, opts)
+}
+
+// Provide2Err creates a new service factory from a typed function with two dependencies returning an error.
+func Provide2Err[A, B, T any](function func(A, B) (T, error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		service, err := function(typedArg[A](in[0]), typedArg[B](in[1]))
+		return service, nil, err
+	}, opts)
+}
+
+// Provide2Close creates a new service factory from a typed function with two dependencies
+// returning a close callback.
+func Provide2Close[A, B, T any](function func(A, B) (T, func() error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		service, closeFn := function(typedArg[A](in[0]), typedArg[B](in[1]))
+		return service, closeFn, nil
+	}, opts)
+}
+
+// Provide2CloseErr creates a new service factory from a typed function with two dependencies
+// returning a close callback and an error.
+func Provide2CloseErr[A, B, T any](function func(A, B) (T, func() error, error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		return function(typedArg[A](in[0]), typedArg[B](in[1]))
+	}, opts)
+}
+
+// Provide3 creates a new service factory from a typed function with three dependencies.
+func Provide3[A, B, C, T any](function func(A, B, C) T, opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		return function(typedArg[A](in[0]), typedArg[B](in[1]), typedArg[C](in[2])), nil, nil
+	}, opts)
+}
+
+// Provide3Err creates a new service factory from a typed function with three dependencies returning an error.
+func Provide3Err[A, B, C, T any](function func(A, B, C) (T, error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		service, err := function(typedArg[A](in[0]), typedArg[B](in[1]), typedArg[C](in[2]))
+		return service, nil, err
+	}, opts)
+}
+
+// Provide3Close creates a new service factory from a typed function with three dependencies
+// returning a close callback.
+func Provide3Close[A, B, C, T any](function func(A, B, C) (T, func() error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		service, closeFn := function(typedArg[A](in[0]), typedArg[B](in[1]), typedArg[C](in[2]))
+		return service, closeFn, nil
+	}, opts)
+}
+
+// Provide3CloseErr creates a new service factory from a typed function with three dependencies
+// returning a close callback and an error.
+func Provide3CloseErr[A, B, C, T any](function func(A, B, C) (T, func() error, error), opts ...FactoryOption) *Factory {
+	return newTypedFactory(function, func(in []reflect.Value) (T, func() error, error) {
+		return function(typedArg[A](in[0]), typedArg[B](in[1]), typedArg[C](in[2]))
+	}, opts)
+}
+
+// newTypedFactory creates a new service factory invoking the typed function by the call function.
+//
+// The call function output is converted to the output layout of the typed function,
+// so the factory is registered the same way as the NewFactory one.
+func newTypedFactory[T any](
+	function any, call func(inValues []reflect.Value) (T, func() error, error), opts []FactoryOption,
+) *Factory {
+	// Detect optional outputs of the typed function.
+	funcType := reflect.TypeOf(function)
+	hasClose := funcType.NumOut() > 1 && isCloseCallback(funcType.Out(1))
+	hasError := funcType.NumOut() > 1 && isErrorInterface(funcType.Out(funcType.NumOut()-1))
+
+	// Invoke the typed call function instead of reflection.
+	callFn := func(inValues []reflect.Value) []reflect.Value {
+		service, closeFn, err := call(inValues)
+		outValues := []reflect.Value{reflect.ValueOf(&service).Elem()}
+		if hasClose {
+			outValues = append(outValues, reflect.ValueOf(closeFn))
+		}
+		if hasError {
+			outValues = append(outValues, reflect.ValueOf(&err).Elem())
+		}
+		return outValues
+	}
+
+	// Register the factory of the function declared by the caller of the Provide function.
+	return newFunctionFactory(function, getCallerSource(2), callFn, opts)
+}
+
+// typedArg returns the resolved input value as the typed argument.
+func typedArg[A any](value reflect.Value) A {
+	arg, _ := value.Interface().(A)
+	return arg
+}
@@ -0,0 +1,122 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestProvide tests typed factories interoperating with reflection-based ones.
+func TestProvide(t *testing.T) {
+	var events []string
+	closer := func(name string) func() error {
+		return func() error {
+			events = append(events, "close "+name)
+			return nil
+		}
+	}
+
+	// Run container.
+	equal(t, Run(
+		NewFactory(func() *testFmtLeaf { return &testFmtLeaf{} }),
+		Provide1(func(*testFmtLeaf) *testFmtMid { return &testFmtMid{} }),
+		Provide2Close(func(*testFmtMid, Optional[int]) (*testFmtRootA, func() error) {
+			return &testFmtRootA{}, closer("root a")
+		}),
+		Provide0[fmt.Stringer](func() fmt.Stringer { return &testStringer{} }),
+		Provide3Err(func(*testFmtLeaf, *testFmtRootA, fmt.Stringer) (string, error) {
+			return "service", nil
+		}),
+		NewFactory(func(s string, _ Multiple[fmt.Stringer]) *testFmtRootB { return &testFmtRootB{} }),
+		NewEntrypoint(func(s string, _ *testFmtRootB, stringer fmt.Stringer, inspector *Inspector) {
+			events = append(events, "entrypoint "+s+" "+stringer.String())
+			factory := withoutBuiltins(inspector.Factories())[1]
+			equal(t, factory.Name, "Factory[func(*gontainer.testFmtLeaf) *gontainer.testFmtMid]")
+			equal(t, strings.Contains(factory.Source, "provide_test.go:"), true)
+		}),
+	), nil)
+	equal(t, events, []string{"entrypoint service stringer", "close root a"})
+}
+
+// TestProvideErrors tests errors of typed factories.
+func TestProvideErrors(t *testing.T) {
+	t.Run("FactoryError", func(t *testing.T) {
+		err := Run(
+			Provide0Err(func() (*testFmtLeaf, error) { return nil, errors.New("leaf boom") }),
+			Provide1CloseErr(func(*testFmtLeaf) (*testFmtMid, func() error, error) {
+				return &testFmtMid{}, nil, nil
+			}),
+			NewEntrypoint(func(*testFmtMid) {}),
+		)
+		equal(t, errors.Is(err, ErrFactoryReturnedError), true)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"leaf boom\n\n"+
+			"Traceback:\n"+
+			"  Factory for *gontainer.testFmtLeaf\n"+
+			"  Factory for *gontainer.testFmtMid\n"+
+			"  Entrypoint")
+	})
+
+	t.Run("CloseError", func(t *testing.T) {
+		err := Run(
+			Provide0Close(func() (*testFmtLeaf, func() error) {
+				return &testFmtLeaf{}, func() error { return errors.New("close boom") }
+			}),
+			NewEntrypoint(func(*testFmtLeaf) {}),
+		)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"close boom\n\n"+
+			"Source:\n"+
+			"  Factory for *gontainer.testFmtLeaf")
+	})
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		err := Run(
+			Provide0(func() any { return nil }),
+			NewEntrypoint(func() {}),
+		)
+		equal(t, err.Error(), "invalid signature: func() interface {}")
+	})
+}
+
+// BenchmarkProvide benchmarks typed factories against reflection-based ones.
+func BenchmarkProvide(b *testing.B) {
+	b.Run("NewFactory", func(b *testing.B) {
+		for index := 0; index < b.N; index++ {
+			_ = Run(
+				NewFactory(func() *testFmtLeaf { return &testFmtLeaf{} }),
+				NewFactory(func(*testFmtLeaf) *testFmtMid { return &testFmtMid{} }),
+				NewFactory(func(*testFmtLeaf, *testFmtMid) *testFmtRootA { return &testFmtRootA{} }),
+				NewEntrypoint(func(*testFmtRootA) {}),
+			)
+		}
+	})
+	b.Run("Provide", func(b *testing.B) {
+		for index := 0; index < b.N; index++ {
+			_ = Run(
+				Provide0(func() *testFmtLeaf { return &testFmtLeaf{} }),
+				Provide1(func(*testFmtLeaf) *testFmtMid { return &testFmtMid{} }),
+				Provide2(func(*testFmtLeaf, *testFmtMid) *testFmtRootA { return &testFmtRootA{} }),
+				NewEntrypoint(func(*testFmtRootA) {}),
+			)
+		}
+	})
+}
@@ -0,0 +1,152 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Readier is the interface for services becoming ready asynchronously, e.g. after a cache preload.
+//
+// Services may also signal readiness with a `Ready() <-chan struct{}` method
+// returning a channel closed when the service is ready.
+type Readier interface {
+	// Ready blocks until the service is ready or the context is done.
+	Ready(ctx context.Context) error
+}
+
+// WithWaitReady returns an option that delays the entrypoint until its dependencies are ready.
+//
+// All spawned dependencies of the entrypoint, direct and transitive, implementing Readier
+// or a `Ready() <-chan struct{}` method are awaited concurrently. When the timeout
+// is positive and a service does not become ready in time, the entrypoint is not invoked
+// and the error names the service. Zero timeout waits without a limit.
+//
+// Example:
+//
+//	gontainer.NewEntrypoint(runServer, gontainer.WithWaitReady(30*time.Second))
+func WithWaitReady(timeout time.Duration) waitReadyOpt {
+	return waitReadyOpt{timeout: timeout}
+}
+
+// waitReadyOpt delays the entrypoint until its dependencies are ready.
+type waitReadyOpt struct {
+	timeout time.Duration
+}
+
+// applyEntrypoint applies the option to the entrypoint settings.
+func (o waitReadyOpt) applyEntrypoint(s *entrypointSettings) {
+	s.waitReady = true
+	s.readyTimeout = o.timeout
+}
+
+// waitReady waits until all spawned dependencies of the factory are ready.
+func (r *registry) waitReady(fact *factory) error {
+	// Prepare the readiness context.
+	ctx := context.Background()
+	if fact.readyTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, fact.readyTimeout)
+		defer cancel()
+	}
+
+	// Wait for all dependencies concurrently.
+	dependencies := r.findSpawnedDependencies(fact)
+	readyErrors := make([]error, len(dependencies))
+	var wg sync.WaitGroup
+	for index, depFact := range dependencies {
+		ready := depFact.getReady()
+		if ready == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(index int, ready func(ctx context.Context) error) {
+			defer wg.Done()
+			readyErrors[index] = ready(ctx)
+		}(index, ready)
+	}
+	wg.Wait()
+
+	// Collect errors in the order of dependencies.
+	var errs errorGroup
+	for index, err := range readyErrors {
+		if err != nil {
+			errs = append(errs, newServiceNotReadyError(dependencies[index], err))
+		}
+	}
+
+	// Return a single error as is.
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errs
+	}
+}
+
+// findSpawnedDependencies returns spawned direct and transitive dependencies of the factory breadth first.
+func (r *registry) findSpawnedDependencies(fact *factory) []*factory {
+	var results []*factory
+	visited := map[*factory]bool{}
+	queue := []*factory{fact}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, inType := range current.inTypes {
+			for _, depFact := range r.findDependencies(inType, current.module) {
+				if visited[depFact] || !depFact.getIsSpawned() {
+					continue
+				}
+				visited[depFact] = true
+				results = append(results, depFact)
+				queue = append(queue, depFact)
+			}
+		}
+	}
+	return results
+}
+
+// getReady returns the readiness function of the spawned factory service or nil.
+func (f *factory) getReady() func(ctx context.Context) error {
+	// Services which have not been spawned are not awaited.
+	outValue := f.getOutValue()
+	if !outValue.IsValid() || isNilValue(outValue) {
+		return nil
+	}
+
+	// Detect the readiness method of the service.
+	switch service := outValue.Interface().(type) {
+	case Readier:
+		return service.Ready
+	case interface{ Ready() <-chan struct{} }:
+		return func(ctx context.Context) error {
+			select {
+			case <-service.Ready():
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testWarmup implements Readier becoming ready after a warm-up.
+type testWarmup struct{ ready chan struct{} }
+
+func (w *testWarmup) Ready(ctx context.Context) error {
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// testSignal signals readiness with a channel.
+type testSignal struct{ ready chan struct{} }
+
+func (s *testSignal) Ready() <-chan struct{} { return s.ready }
+
+// TestWaitReady tests entrypoints waiting for readiness of dependencies.
+func TestWaitReady(t *testing.T) {
+	warmedUp := atomic.Bool{}
+	equal(t, Run(
+		NewFactory(func() *testWarmup {
+			warmup := &testWarmup{ready: make(chan struct{})}
+			go func() {
+				time.Sleep(10 * time.Millisecond)
+				warmedUp.Store(true)
+				close(warmup.ready)
+			}()
+			return warmup
+		}),
+		NewFactory(func() *testSignal {
+			signal := &testSignal{ready: make(chan struct{})}
+			close(signal.ready)
+			return signal
+		}),
+		NewFactory(func(*testWarmup) *testFmtMid { return &testFmtMid{} }),
+		NewEntrypoint(func(*testFmtMid, *testSignal) {
+			equal(t, warmedUp.Load(), true)
+		}, WithWaitReady(5*time.Second)),
+	), nil)
+}
+
+// TestWaitReadyTimeout tests the traceback of a service never becoming ready.
+func TestWaitReadyTimeout(t *testing.T) {
+	invoked := false
+	err := Run(
+		NewFactory(func() *testWarmup { return &testWarmup{ready: make(chan struct{})} }),
+		NewFactory(func() *testSignal { return &testSignal{ready: make(chan struct{})} }),
+		NewFactory(func(*testWarmup) *testFmtMid { return &testFmtMid{} }),
+		NewEntrypoint(func(*testFmtMid, *testSignal) {
+			invoked = true
+		}, WithWaitReady(10*time.Millisecond)),
+	)
+	equal(t, invoked, false)
+	equal(t, errors.Is(err, ErrServiceNotReady), true)
+	equal(t, errors.Is(err, context.DeadlineExceeded), true)
+	equal(t, normalizeSourceLines(err.Error()), ""+
+		"service not ready: *gontainer.testSignal: context deadline exceeded\n\n"+
+		"Traceback:\n"+
+		"  Factory for *gontainer.testSignal\n"+
+		"  Entrypoint\n\n"+
+		"service not ready: *gontainer.testWarmup: context deadline exceeded\n\n"+
+		"Traceback:\n"+
+		"  Factory for *gontainer.testWarmup\n"+
+		"  Entrypoint")
+}
+
+// TestWaitReadyDisabled tests entrypoints are not delayed without the option.
+func TestWaitReadyDisabled(t *testing.T) {
+	invoked := false
+	equal(t, Run(
+		NewFactory(func() *testWarmup { return &testWarmup{ready: make(chan struct{})} }),
+		NewEntrypoint(func(*testWarmup) { invoked = true }),
+	), nil)
+	equal(t, invoked, true)
+}
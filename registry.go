@@ -18,8 +18,10 @@
 package gontainer
 
 import (
+	"context"
 	"reflect"
 	"sync"
+	"time"
 )
 
 // registry contains all defined factories.
@@ -27,27 +29,192 @@ type registry struct {
 	factories   []*factory
 	sequence    []*factory
 	entrypoints []*factory
-	mutex       sync.Mutex
+	inactive    []*factory
+	profiles    []string
+	module      *module
+	condition   *condition
+	index       *typeIndex
+
+	// Validation, spawning and reporting settings.
+	unusedCheck      bool
+	unspawnedReports []func(unspawned []FactoryInfo)
+	eager            bool
+	parallel         bool
+	workers          chan struct{}
+	parallelClose    bool
+	autoClose        bool
+	healthTimeout    time.Duration
+	started          bool
+	stopCtx          context.Context
+	stopped          chan struct{}
+	eventHandlers    []func(event Event)
+	tracer           Tracer
+	traceCtx         context.Context
+
+	mutex sync.Mutex
 }
 
 // registerFactory registers factory function in the registry.
 func (r *registry) registerFactory(fact *factory) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
+	fact.module = r.module
+	fact.condition = r.condition
+	fact.active = r.condition.isSatisfied()
+	if !fact.active {
+		fact.inactiveReason = inactiveByCondition
+		r.inactive = append(r.inactive, fact)
+		return
+	}
 	r.factories = append(r.factories, fact)
+	r.index = nil
 }
 
 // registerEntrypoint registers entrypoint function in the registry.
 func (r *registry) registerEntrypoint(fact *factory) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
+	fact.module = r.module
+	fact.condition = r.condition
+	fact.active = r.condition.isSatisfied()
+	if !fact.active {
+		fact.inactiveReason = inactiveByCondition
+		r.inactive = append(r.inactive, fact)
+		return
+	}
 	r.entrypoints = append(r.entrypoints, fact)
 }
 
+// enterModule starts registration of options in a nested module.
+func (r *registry) enterModule(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.module = newModule(name, r.module)
+}
+
+// leaveModule finishes registration of options in the current module.
+func (r *registry) leaveModule() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.module = r.module.parent
+}
+
+// enterCondition starts registration of options in a nested condition.
+func (r *registry) enterCondition(name string, satisfied bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.condition = &condition{name: name, satisfied: satisfied, parent: r.condition}
+}
+
+// leaveCondition finishes registration of options in the current condition.
+func (r *registry) leaveCondition() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.condition = r.condition.parent
+}
+
+// activateProfiles deactivates factories and entrypoints of inactive profiles.
+func (r *registry) activateProfiles() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.factories = r.filterProfiles(r.factories)
+	r.entrypoints = r.filterProfiles(r.entrypoints)
+	r.index = nil
+}
+
+// filterProfiles returns factories of active profiles and moves the rest to inactive ones.
+func (r *registry) filterProfiles(factories []*factory) []*factory {
+	results := make([]*factory, 0, len(factories))
+	for _, fact := range factories {
+		if !fact.isProfileActive(r.profiles) {
+			fact.active = false
+			fact.inactiveReason = inactiveByProfile
+			r.inactive = append(r.inactive, fact)
+			continue
+		}
+		results = append(results, fact)
+	}
+	return results
+}
+
+// applyOverrides deactivates factories replaced by overriding factories.
+//
+// A factory is replaced when its service would be resolved for the output type of
+// an overriding factory, e.g. an override of an interface type replaces factories
+// of all implementations, the same way resolution matches services.
+func (r *registry) applyOverrides() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Collect overriding factories.
+	var overrides []*factory
+	for _, fact := range r.factories {
+		if fact.override {
+			overrides = append(overrides, fact)
+		}
+	}
+
+	// Deactivate replaced factories.
+	results := make([]*factory, 0, len(r.factories))
+	for _, fact := range r.factories {
+		if overriding := findOverriding(fact, overrides); !fact.override && overriding != nil {
+			fact.active = false
+			fact.inactiveReason = inactiveByOverride
+			fact.overriddenBy = overriding
+			r.inactive = append(r.inactive, fact)
+			continue
+		}
+		results = append(results, fact)
+	}
+	r.factories = results
+	r.index = nil
+}
+
+// findOverriding returns the first overriding factory providing a service of the factory type, or nil.
+func findOverriding(fact *factory, overrides []*factory) *factory {
+	outType := fact.getOutType()
+	if outType == nil {
+		return nil
+	}
+	for _, overriding := range overrides {
+		if isTypeProvided(outType, overriding.getOutType()) {
+			return overriding
+		}
+	}
+	return nil
+}
+
 // validateRegistry validates all registered state.
-// Validate checks for availability of all non-optional types
-// and for possible circular dependencies between factories.
+// Validate checks for availability of all non-optional types,
+// for possible circular dependencies between factories,
+// for presence of entrypoints and optionally for unused factories.
 func (r *registry) validateRegistry() error {
+	// Validate all registered factories.
+	errs := r.validateFactories()
+
+	// Validate for entrypoints count.
+	if len(r.entrypoints) == 0 {
+		errs = append(errs, ErrNoEntrypointsProvided)
+	}
+
+	// Validate for unused factories when requested.
+	if r.unusedCheck {
+		for _, fact := range r.findUnusedFactories() {
+			errs = append(errs, newFactoryUnusedError(fact))
+		}
+	}
+
+	// Return nil if no errors found.
+	if len(errs) == 0 {
+		return nil
+	}
+
+	// Return collected errors.
+	return errs
+}
+
+// validateFactories validates all registered factories and entrypoints.
+func (r *registry) validateFactories() errorGroup {
 	// Prepare result errors accumulator.
 	var errs errorGroup
 
@@ -71,10 +238,16 @@ func (r *registry) validateRegistry() error {
 				continue
 			}
 
+			// Is this type wrapped to the `Tagged[type, tag]`?
+			_, _, isTagged := isTaggedType(inType)
+			if isTagged {
+				continue
+			}
+
 			// Could a factory for this type be resolved?
-			foundFactories := r.findFactories(inType)
+			foundFactories := r.findFactories(inType, fact.module)
 			if len(foundFactories) == 0 {
-				errs = append(errs, newDependencyNotResolvedError(fact, inType))
+				errs = append(errs, r.newDependencyNotFoundError(fact, inType, fact.module))
 				continue
 			}
 		}
@@ -90,56 +263,21 @@ func (r *registry) validateRegistry() error {
 			continue
 		}
 
-		// Validate uniqueness of the factory output type.
-		factories := r.findFactories(outType)
+		// Validate uniqueness of the factory output type within the factory module.
+		factories := r.findFactories(outType, fact.module)
 		if len(factories) > 1 {
 			errs = append(errs, newFactoryTypeDuplicatedError(fact))
 		}
 	}
 
 	// Validate for circular dependencies.
-	for _, fact := range r.factories {
-		factories := []*factory{fact}
-	iteration:
-		for len(factories) > 0 {
-			nextFact := factories[0]
-			factories = factories[1:]
-
-			for _, inType := range nextFact.inTypes {
-				// Is this type wrapped to the `Optional[type]`?
-				innerType, isOptional := isOptionalType(inType)
-				if isOptional {
-					inType = innerType
-				}
-
-				// Is this type wrapped to the `Multiple[type]`?
-				innerType, isMultiple := isMultipleType(inType)
-				if isMultiple {
-					inType = innerType
-				}
-
-				// Walk through all factories for this in argument type.
-				typeFactories := r.findFactories(inType)
-				for _, factoryForType := range typeFactories {
-					if factoryForType == fact {
-						errs = append(errs, newCircularDependencyError(fact))
-						break iteration
-					}
-				}
-
-				factories = append(factories, typeFactories...)
-			}
-		}
-	}
-
-	// Validate for entrypoints count.
-	if len(r.entrypoints) == 0 {
-		errs = append(errs, ErrNoEntrypointsProvided)
+	for _, cycle := range r.findCycles() {
+		errs = append(errs, newCircularDependencyError(cycle))
 	}
 
-	// Return nil if no errors found.
-	if len(errs) == 0 {
-		return nil
+	// Validate for contradictory order constraints.
+	for _, fact := range findOrderCycles(r.factories) {
+		errs = append(errs, newContradictoryOrderError(fact))
 	}
 
 	// Return collected errors.
@@ -153,17 +291,8 @@ func (r *registry) invokeEntrypoints() error {
 
 	// Invoke all functions in the registry.
 	for _, fact := range r.entrypoints {
-		// Invoke the factory.
-		if err := r.invokeFactory(fact); err != nil {
-			errs = append(errs, newFactoryResolveFailedError(fact, err))
-
-			// The entrypoint was not invoked.
-			continue
-		}
-
-		// Handle factory error.
-		if err := fact.getOutError(); err != nil {
-			errs = append(errs, newEntrypointReturnedErrorError(fact, err))
+		if err := r.invokeEntrypoint(fact); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
@@ -176,18 +305,54 @@ func (r *registry) invokeEntrypoints() error {
 	return errs
 }
 
-// closeFactories closes all factories in the reverse order.
+// invokeEntrypoint invokes the entrypoint restarting it according to its restart policy.
+func (r *registry) invokeEntrypoint(fact *factory) error {
+	for attempt := 1; ; attempt++ {
+		// Invoke the factory.
+		if err := r.invokeTraced(nil, fact, InvocationEntrypoint, attempt); err != nil {
+			// The entrypoint was not invoked.
+			return newFactoryResolveFailedError(fact, err)
+		}
+
+		// Report the entrypoint error.
+		err := fact.getOutError()
+		if err != nil {
+			r.emitEvent(fact, Event{Kind: EventEntrypointFailed, Err: err, Attempt: attempt, Duration: fact.callDuration})
+		}
+
+		// Wait before the restart unless the policy gives up or the container is stopped.
+		restart := fact.restart.shouldRestart(attempt, err) && !r.isStopped()
+		if restart {
+			delay := fact.restart.getBackoff(attempt)
+			r.emitEvent(fact, Event{Kind: EventEntrypointRestarting, Err: err, Attempt: attempt, Delay: delay})
+			restart = r.waitRestart(delay)
+		}
+
+		// Handle the entrypoint result when it is not restarted.
+		if !restart {
+			if err != nil {
+				return newEntrypointReturnedErrorError(fact, err)
+			}
+			return nil
+		}
+	}
+}
+
+// closeFactories closes all factories in the reverse order,
+// or concurrently respecting dependencies with WithParallelClose.
 func (r *registry) closeFactories() error {
+	// Close factories concurrently when requested.
+	if r.parallelClose {
+		return r.closeFactoriesParallel()
+	}
+
 	// Prepare result errors accumulator.
 	var errs errorGroup
 
 	// Close all spawned factories in the reverse order.
 	for index := len(r.sequence) - 1; index >= 0; index-- {
-		fact := r.sequence[index]
-
-		// Invoke close callback function.
-		if err := fact.getOutClose()(); err != nil {
-			errs = append(errs, newFactoryCloseFailedError(fact, err))
+		if err := r.closeFactory(r.sequence[index]); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
@@ -200,28 +365,53 @@ func (r *registry) closeFactories() error {
 	return errs
 }
 
+// closeFactory invokes the close callback of the spawned factory.
+func (r *registry) closeFactory(fact *factory) error {
+	// Invoke close callback function.
+	finish := r.startTrace(nil, fact, InvocationClose, 0)
+	startTime := time.Now()
+	err := fact.getOutClose()()
+	finish(err)
+	r.emitEvent(fact, Event{Kind: EventFactoryClosed, Err: err, Duration: time.Since(startTime)})
+
+	// Handle close callback error.
+	if err != nil {
+		return newFactoryCloseFailedError(fact, err)
+	}
+	return nil
+}
+
 // resolveService resolves and returns the service based on the type.
-func (r *registry) resolveService(serviceType reflect.Type) (reflect.Value, error) {
+//
+// Only services visible from the module of the requester are resolved, the nil
+// requester stands for the top level of the container.
+func (r *registry) resolveService(serviceType reflect.Type, requester *factory) (reflect.Value, error) {
 	// Is a target type - optional container?
 	innerType, isOptional := isOptionalType(serviceType)
 	if isOptional {
-		return r.resolveOptional(serviceType, innerType)
+		return r.resolveOptional(serviceType, innerType, requester)
 	}
 
 	// Is a target type - multiple container?
 	innerType, isMultiple := isMultipleType(serviceType)
 	if isMultiple {
-		return r.resolveMultiple(serviceType, innerType)
+		return r.resolveMultiple(serviceType, innerType, requester)
+	}
+
+	// Is a target type - tagged container?
+	innerType, tagType, isTagged := isTaggedType(serviceType)
+	if isTagged {
+		return r.resolveTagged(serviceType, innerType, tagType, requester)
 	}
 
 	// Resolve regular service.
-	return r.resolveRegular(serviceType)
+	return r.resolveRegular(serviceType, requester)
 }
 
 // resolveOptional resolves a service wrapped with an optional type.
-func (r *registry) resolveOptional(optionalType, serviceType reflect.Type) (reflect.Value, error) {
+func (r *registry) resolveOptional(optionalType, serviceType reflect.Type, requester *factory) (reflect.Value, error) {
 	// Resolve all services by specified type.
-	serviceValues, err := r.resolveByType(serviceType)
+	serviceValues, err := r.resolveByType(serviceType, requester)
 	if err != nil {
 		return reflect.Value{}, err
 	}
@@ -242,9 +432,9 @@ func (r *registry) resolveOptional(optionalType, serviceType reflect.Type) (refl
 }
 
 // resolveMultiple resolves all services fits to the multiple type.
-func (r *registry) resolveMultiple(multipleType, serviceType reflect.Type) (reflect.Value, error) {
-	// Resolve all services by specified type.
-	serviceValues, err := r.resolveByType(serviceType)
+func (r *registry) resolveMultiple(multipleType, serviceType reflect.Type, requester *factory) (reflect.Value, error) {
+	// Resolve all services by specified type in the configured order.
+	serviceValues, err := r.spawnFactories(requester, orderFactories(r.findFactories(serviceType, requester.getScope())))
 	if err != nil {
 		return reflect.Value{}, err
 	}
@@ -253,10 +443,22 @@ func (r *registry) resolveMultiple(multipleType, serviceType reflect.Type) (refl
 	return newMultipleValue(multipleType, serviceValues), nil
 }
 
+// resolveTagged resolves all services fits to the tagged type.
+func (r *registry) resolveTagged(taggedType, serviceType, tagType reflect.Type, requester *factory) (reflect.Value, error) {
+	// Resolve all services by specified type and annotation type in the configured order.
+	serviceValues, err := r.spawnFactories(requester, orderFactories(r.findTaggedFactories(serviceType, tagType, requester.getScope())))
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	// Return resolved services in a tagged box type.
+	return newTaggedValue(taggedType, serviceValues), nil
+}
+
 // resolveRegular resolves a regular service.
-func (r *registry) resolveRegular(serviceType reflect.Type) (reflect.Value, error) {
+func (r *registry) resolveRegular(serviceType reflect.Type, requester *factory) (reflect.Value, error) {
 	// Resolve all services by specified type.
-	resolvedValues, err := r.resolveByType(serviceType)
+	resolvedValues, err := r.resolveByType(serviceType, requester)
 	if err != nil {
 		return reflect.Value{}, err
 	}
@@ -267,7 +469,7 @@ func (r *registry) resolveRegular(serviceType reflect.Type) (reflect.Value, erro
 	// unregistered type `Config` triggers an error, while resolving `gontainer.Optional[Config]`
 	// returns a zero-value box.
 	if len(resolvedValues) == 0 {
-		return reflect.Value{}, newDependencyNotResolvedError(nil, serviceType)
+		return reflect.Value{}, r.newDependencyNotFoundError(nil, serviceType, requester.getScope())
 	}
 
 	// Pick first found service value.
@@ -275,17 +477,20 @@ func (r *registry) resolveRegular(serviceType reflect.Type) (reflect.Value, erro
 }
 
 // resolveByType resolves all service fits to specified type.
-func (r *registry) resolveByType(serviceType reflect.Type) ([]reflect.Value, error) {
+func (r *registry) resolveByType(serviceType reflect.Type, requester *factory) ([]reflect.Value, error) {
 	// Lookup factory definition by an output type.
-	factories := r.findFactories(serviceType)
+	return r.spawnFactories(requester, r.findFactories(serviceType, requester.getScope()))
+}
 
+// spawnFactories spawns all specified factories requested by the requester and returns their output values.
+func (r *registry) spawnFactories(requester *factory, factories []*factory) ([]reflect.Value, error) {
 	// Prepare result values slice.
 	results := make([]reflect.Value, 0, len(factories))
 
 	// Spawn all found factories.
 	for _, fact := range factories {
 		// Handle found factory definition.
-		if err := r.spawnFactory(fact); err != nil {
+		if err := r.spawnFactory(requester, fact); err != nil {
 			return nil, newFactoryResolveFailedError(fact, err)
 		}
 
@@ -294,40 +499,96 @@ func (r *registry) resolveByType(serviceType reflect.Type) ([]reflect.Value, err
 			return nil, newFactoryReturnedErrorError(fact, err)
 		}
 
+		// Record the dependency of the requester.
+		if requester != nil {
+			requester.addSpawnedDependency(fact)
+		}
+
 		// Handle the factory result output.
-		results = append(results, fact.getOutValue())
+		value := fact.getOutValue()
+		if fact.builtin {
+			value = bindRequester(value, requester)
+		}
+		results = append(results, value)
 	}
 
 	// Return resolved values.
 	return results, nil
 }
 
-// findFactories lookups for all factories for an output type in the registry.
-func (r *registry) findFactories(serviceType reflect.Type) []*factory {
+// bindRequester binds built-in resolution services to the requester,
+// so services resolved dynamically are recorded as dependencies of the requester.
+func bindRequester(value reflect.Value, requester *factory) reflect.Value {
+	if requester == nil {
+		return value
+	}
+	switch service := value.Interface().(type) {
+	case *Resolver:
+		return reflect.ValueOf(&Resolver{registry: service.registry, requester: requester})
+	case *Invoker:
+		return reflect.ValueOf(&Invoker{registry: service.registry, requester: requester})
+	}
+	return value
+}
+
+// findFactories lookups for all factories for an output type visible from the scope module.
+func (r *registry) findFactories(serviceType reflect.Type, scope *module) []*factory {
 	// Prepare result factories slice.
 	var factories []*factory
 
-	// Lookup for factories in the registry.
-	for _, fact := range r.factories {
-		outType := fact.getOutType()
+	// Lookup for factories in the type index.
+	for _, fact := range r.getIndex().lookup(serviceType) {
+		// Desired service type is visible from the scope.
+		if fact.isVisibleFrom(scope) {
+			factories = append(factories, fact)
+		}
+	}
 
-		// Skip factories without an output type.
-		if outType == nil {
-			continue
+	// Return matched factories.
+	return factories
+}
+
+// findHiddenFactories lookups for all factories for an output type not visible from the scope module.
+func (r *registry) findHiddenFactories(serviceType reflect.Type, scope *module) []*factory {
+	// Prepare result factories slice.
+	var factories []*factory
+
+	// Lookup for factories in the type index.
+	for _, fact := range r.getIndex().lookup(serviceType) {
+		if !fact.isVisibleFrom(scope) {
+			factories = append(factories, fact)
 		}
+	}
+
+	// Return matched factories.
+	return factories
+}
+
+// findInactiveFactories lookups for all inactive factories for an output type.
+func (r *registry) findInactiveFactories(serviceType reflect.Type) []*factory {
+	// Prepare result factories slice.
+	var factories []*factory
 
-		// Desired service type matched.
-		if outType == serviceType {
+	// Lookup for factories skipped by conditions or profiles.
+	for _, fact := range r.inactive {
+		if isTypeProvided(fact.getOutType(), serviceType) {
 			factories = append(factories, fact)
-			continue
 		}
+	}
 
-		// Desired service type implements an interface.
-		if serviceType.Kind() == reflect.Interface {
-			if outType.Implements(serviceType) {
-				factories = append(factories, fact)
-				continue
-			}
+	// Return matched factories.
+	return factories
+}
+
+// findTaggedFactories lookups for all factories for an output type annotated with a tag type.
+func (r *registry) findTaggedFactories(serviceType, tagType reflect.Type, scope *module) []*factory {
+	// Prepare result factories slice.
+	var factories []*factory
+
+	// Filter factories by the annotation type.
+	for _, fact := range r.findFactories(serviceType, scope) {
+		if fact.hasAnnotation(tagType) {
+			factories = append(factories, fact)
 		}
 	}
 
@@ -335,8 +596,43 @@ func (r *registry) findFactories(serviceType reflect.Type) []*factory {
 	return factories
 }
 
-// spawnFactory instantiates specified factory definition.
-func (r *registry) spawnFactory(fact *factory) error {
+// findDependencies lookups for all factories which may be used to resolve an input type.
+func (r *registry) findDependencies(inType reflect.Type, scope *module) []*factory {
+	// Is this type wrapped to the `Optional[type]`?
+	innerType, isOptional := isOptionalType(inType)
+	if isOptional {
+		inType = innerType
+	}
+
+	// Is this type wrapped to the `Multiple[type]`?
+	innerType, isMultiple := isMultipleType(inType)
+	if isMultiple {
+		inType = innerType
+	}
+
+	// Is this type wrapped to the `Tagged[type, tag]`?
+	innerType, tagType, isTagged := isTaggedType(inType)
+	if isTagged {
+		return r.findTaggedFactories(innerType, tagType, scope)
+	}
+
+	// Lookup for factories by the type.
+	return r.findFactories(inType, scope)
+}
+
+// newDependencyNotFoundError reports a missing dependency distinguishing private and inactive services.
+func (r *registry) newDependencyNotFoundError(requester *factory, missing reflect.Type, scope *module) error {
+	if hidden := r.findHiddenFactories(missing, scope); len(hidden) > 0 {
+		return newDependencyPrivateError(requester, missing, hidden[0])
+	}
+	if inactive := r.findInactiveFactories(missing); len(inactive) > 0 {
+		return newDependencyInactiveError(requester, missing, inactive[0])
+	}
+	return newDependencyNotResolvedError(requester, missing)
+}
+
+// spawnFactory instantiates specified factory definition requested by the requester.
+func (r *registry) spawnFactory(requester, fact *factory) error {
 	// Lock the factory spawn mutex.
 	fact.spawnMu.Lock()
 	defer fact.spawnMu.Unlock()
@@ -347,11 +643,14 @@ func (r *registry) spawnFactory(fact *factory) error {
 	}
 
 	// Invoke the factory.
-	err := r.invokeFactory(fact)
+	err := r.invokeTraced(requester, fact, InvocationSpawn, 0)
 	if err != nil {
 		return err
 	}
 
+	// Report the factory call.
+	r.emitEvent(fact, Event{Kind: EventFactorySpawned, Err: fact.getOutError(), Duration: fact.callDuration})
+
 	// Save the factory spawn status.
 	fact.setIsSpawned(true)
 
@@ -367,20 +666,27 @@ func (r *registry) spawnFactory(fact *factory) error {
 // invokeFactory calls the factory function and returns output values.
 func (r *registry) invokeFactory(fact *factory) error {
 	// Get or spawn factory input values recursively.
-	inValues := make([]reflect.Value, 0, len(fact.inTypes))
-	for _, inType := range fact.inTypes {
-		// Resolve factory input dependency.
-		inValue, err := r.resolveService(inType)
-		if err != nil {
+	inValues, err := r.resolveInputs(fact)
+	if err != nil {
+		return err
+	}
+
+	// Wait for readiness of dependencies when requested.
+	if fact.waitReady {
+		if err := r.waitReady(fact); err != nil {
 			return err
 		}
-
-		// Append resolved input value.
-		inValues = append(inValues, inValue)
 	}
 
 	// Call the factory using input arguments.
-	outValues := fact.funcValue.Call(inValues)
+	var outValues []reflect.Value
+	startTime := time.Now()
+	if fact.callFn != nil {
+		outValues = fact.callFn(inValues)
+	} else {
+		outValues = fact.funcValue.Call(inValues)
+	}
+	fact.callDuration = time.Since(startTime)
 
 	// Set factory output values.
 	fact.setOutValues(outValues)
@@ -389,6 +695,22 @@ func (r *registry) invokeFactory(fact *factory) error {
 	return nil
 }
 
+// isTypeProvided returns true when a service of the output type satisfies the service type.
+func isTypeProvided(outType, serviceType reflect.Type) bool {
+	// Factories without output type provide nothing.
+	if outType == nil {
+		return false
+	}
+
+	// Desired service type matched.
+	if outType == serviceType {
+		return true
+	}
+
+	// Desired service type implements an interface.
+	return serviceType.Kind() == reflect.Interface && outType.Implements(serviceType)
+}
+
 // isEmptyInterface returns true when argument is an `any` interface.
 func isEmptyInterface(typ reflect.Type) bool {
 	return typ.Kind() == reflect.Interface && typ.NumMethod() == 0
@@ -400,6 +722,15 @@ func isErrorInterface(typ reflect.Type) bool {
 	return typ.Kind() == reflect.Interface && typ.Implements(errType)
 }
 
+// isNilValue returns true when argument is a nil pointer, interface, map, slice, func or channel.
+func isNilValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return value.IsNil()
+	}
+	return false
+}
+
 // isCloseCallback returns true when argument is a close callback function.
 func isCloseCallback(typ reflect.Type) bool {
 	refType := reflect.TypeOf(func() error { return nil })
@@ -130,25 +130,16 @@ func TestRegistryValidateFactories(t *testing.T) {
 				unwrap, ok := err.(interface{ Unwrap() []error })
 				equal(t, ok, true)
 				errs := unwrap.Unwrap()
-				equal(t, len(errs), 3)
+				equal(t, len(errs), 1)
 
 				equal(t, errors.Is(errs[0], ErrCircularDependency), true)
 				equal(t, normalizeSourceLines(errs[0].Error()), ""+
 					"circular dependency\n\n"+
 					"Traceback:\n"+
+					"  Factory for int\n"+
+					"  Factory for string\n"+
+					"  Factory for bool\n"+
 					"  Factory for int")
-
-				equal(t, errors.Is(errs[1], ErrCircularDependency), true)
-				equal(t, normalizeSourceLines(errs[1].Error()), ""+
-					"circular dependency\n\n"+
-					"Traceback:\n"+
-					"  Factory for bool")
-
-				equal(t, errors.Is(errs[2], ErrCircularDependency), true)
-				equal(t, normalizeSourceLines(errs[2].Error()), ""+
-					"circular dependency\n\n"+
-					"Traceback:\n"+
-					"  Factory for string")
 			},
 		},
 		{
@@ -168,7 +159,7 @@ func TestRegistryValidateFactories(t *testing.T) {
 				unwrap, ok := err.(interface{ Unwrap() []error })
 				equal(t, ok, true)
 				errs := unwrap.Unwrap()
-				equal(t, len(errs), 6)
+				equal(t, len(errs), 5)
 
 				equal(t, errors.Is(errs[0], ErrDependencyNotResolved), true)
 				equal(t, normalizeSourceLines(errs[0].Error()), ""+
@@ -198,13 +189,9 @@ func TestRegistryValidateFactories(t *testing.T) {
 				equal(t, normalizeSourceLines(errs[4].Error()), ""+
 					"circular dependency\n\n"+
 					"Traceback:\n"+
+					"  Factory for int\n"+
+					"  Factory for bool\n"+
 					"  Factory for int")
-
-				equal(t, errors.Is(errs[5], ErrCircularDependency), true)
-				equal(t, normalizeSourceLines(errs[5].Error()), ""+
-					"circular dependency\n\n"+
-					"Traceback:\n"+
-					"  Factory for bool")
 			},
 		},
 	}
@@ -255,7 +242,7 @@ func TestRegistryResolveParallel(t *testing.T) {
 	wg.Add(10)
 	for x := 0; x < 10; x++ {
 		go func() {
-			values, err := registry.resolveByType(reflect.TypeOf(true))
+			values, err := registry.resolveByType(reflect.TypeOf(true), nil)
 			equal(t, err, nil)
 			equal(t, values[0].Interface(), true)
 			wg.Done()
@@ -324,7 +311,7 @@ func TestRegistryResolveWithErrors(t *testing.T) {
 	registry := &registry{}
 	equal(t, source.apply(registry), nil)
 
-	value, err := registry.resolveService(reflect.TypeOf(true))
+	value, err := registry.resolveService(reflect.TypeOf(true), nil)
 	equal(t, err != nil, true)
 	equal(t, value.IsValid(), false)
 	equal(t, normalizeSourceLines(fmt.Sprint(err)), ""+
@@ -18,6 +18,8 @@
 package gontainer
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 )
 
@@ -30,18 +32,58 @@ import (
 // If the container has not been started yet, Resolve operates in lazy mode — it instantiates
 // only the requested type and its transitive dependencies on demand.
 //
-// An error is returned if the service of the requested type is not found or cannot be resolved.
+// An error is returned if the service of the requested type is not found or cannot be resolved,
+// or if `varPtr` is not a non-nil pointer.
 type Resolver struct {
-	registry *registry
+	registry  *registry
+	requester *factory
 }
 
 // Resolve sets the required dependency via the pointer.
 func (r *Resolver) Resolve(varPtr any) error {
-	value := reflect.ValueOf(varPtr).Elem()
-	result, err := r.registry.resolveService(value.Type())
+	// Validate the resolver is created by a container.
+	if r == nil || r.registry == nil {
+		return errors.New("invalid resolver: not created by a container")
+	}
+
+	// Validate the pointer argument.
+	ptrValue := reflect.ValueOf(varPtr)
+	if ptrValue.Kind() != reflect.Pointer || ptrValue.IsNil() {
+		return fmt.Errorf("invalid type: %T", varPtr)
+	}
+
+	// Resolve the service by the pointer element type.
+	value := ptrValue.Elem()
+	result, err := r.registry.resolveService(value.Type(), r.requester)
 	if err != nil {
 		return err
 	}
 	value.Set(result)
 	return nil
 }
+
+// Resolve resolves a service of type T using the resolver.
+//
+// Example:
+//
+//	db, err := gontainer.Resolve[*Database](resolver)
+func Resolve[T any](r *Resolver) (T, error) {
+	var result T
+	if err := r.Resolve(&result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// MustResolve resolves a service of type T using the resolver and panics on failure.
+//
+// Example:
+//
+//	db := gontainer.MustResolve[*Database](resolver)
+func MustResolve[T any](r *Resolver) T {
+	result, err := Resolve[T](r)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
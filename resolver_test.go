@@ -18,6 +18,7 @@
 package gontainer
 
 import (
+	"errors"
 	"sync/atomic"
 	"testing"
 )
@@ -50,3 +51,74 @@ func TestResolverService(t *testing.T) {
 	// Assert started flag is set.
 	equal(t, started.Load(), true)
 }
+
+// TestResolverInvalidArgument tests resolving into invalid pointers.
+func TestResolverInvalidArgument(t *testing.T) {
+	equal(t, Run(
+		NewService(float64(100500)),
+		NewEntrypoint(func(resolver *Resolver) {
+			var value float64
+			equal(t, resolver.Resolve(nil) != nil, true)
+			equal(t, resolver.Resolve(value) != nil, true)
+			equal(t, resolver.Resolve((*float64)(nil)) != nil, true)
+		}),
+	), nil)
+}
+
+// TestResolverNotCreated tests resolvers not created by a container.
+func TestResolverNotCreated(t *testing.T) {
+	_, err := Resolve[float64](&Resolver{})
+	equal(t, err.Error(), "invalid resolver: not created by a container")
+	_, err = Resolve[float64](nil)
+	equal(t, err.Error(), "invalid resolver: not created by a container")
+}
+
+// TestResolveGeneric tests generic resolve helpers.
+func TestResolveGeneric(t *testing.T) {
+	svc1 := &testService1{}
+
+	// Prepare started flag.
+	started := atomic.Bool{}
+
+	// Run container.
+	equal(t, Run(
+		NewService(svc1),
+		NewEntrypoint(func(resolver *Resolver) {
+			started.Store(true)
+
+			value, err := Resolve[*testService1](resolver)
+			equal(t, err, nil)
+			equal(t, value, svc1)
+
+			iface, err := Resolve[interface{ Do1() }](resolver)
+			equal(t, err, nil)
+			equal(t, iface, interface{ Do1() }(svc1))
+
+			optional, err := Resolve[Optional[int]](resolver)
+			equal(t, err, nil)
+			equal(t, optional.Ok(), false)
+
+			missing, err := Resolve[*testService2](resolver)
+			equal(t, errors.Is(err, ErrDependencyNotResolved), true)
+			equal(t, missing, (*testService2)(nil))
+
+			equal(t, MustResolve[*testService1](resolver), svc1)
+			equal(t, recovers(func() { MustResolve[*testService2](resolver) }), true)
+
+			_, err = Resolve[int](nil)
+			equal(t, err != nil, true)
+		}),
+	), nil)
+
+	// Assert started flag is set.
+	equal(t, started.Load(), true)
+}
+
+// recovers reports whether the function panicked.
+func recovers(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return false
+}
@@ -0,0 +1,212 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"context"
+	"time"
+)
+
+// RestartMode defines when an entrypoint is restarted.
+type RestartMode int
+
+const (
+	// RestartNever never restarts the entrypoint.
+	RestartNever RestartMode = iota
+
+	// RestartOnFailure restarts the entrypoint when it returns an error.
+	RestartOnFailure
+
+	// RestartAlways restarts the entrypoint whenever it returns, also without an error,
+	// until the attempts limit is reached or the container is stopped.
+	RestartAlways
+)
+
+// Default backoff delays of restart policies.
+const (
+	defaultRestartBackoff    = 100 * time.Millisecond
+	defaultRestartMaxBackoff = 30 * time.Second
+)
+
+// RestartPolicy defines how an entrypoint is restarted.
+type RestartPolicy struct {
+	// Mode defines when the entrypoint is restarted.
+	Mode RestartMode
+
+	// MaxAttempts is the maximum number of invocations including the first one, zero means no limit.
+	MaxAttempts int
+
+	// Backoff is the delay before the first restart, 100ms by default.
+	// The delay is doubled before every next restart.
+	Backoff time.Duration
+
+	// MaxBackoff is the maximum delay between restarts, 30s by default.
+	MaxBackoff time.Duration
+}
+
+// WithRestart returns an option that supervises the entrypoint with the restart policy.
+//
+// The entrypoint is invoked again with the same dependencies, which stay alive between
+// restarts. Every failure is emitted to event handlers registered with WithEventHandler,
+// and the error of the last invocation is reported when the policy gives up.
+// Entrypoints failing to resolve dependencies are never restarted.
+//
+// Entrypoints are invoked one at a time, so a restarted entrypoint delays the next
+// entrypoints until it is not restarted anymore. Restarts end when the container
+// is closed or the context of WithStopContext is done, interrupting the backoff wait.
+//
+// Example:
+//
+//	gontainer.NewEntrypoint(consumeMessages, gontainer.WithRestart(gontainer.RestartPolicy{
+//	    Mode:        gontainer.RestartOnFailure,
+//	    MaxAttempts: 5,
+//	    Backoff:     time.Second,
+//	}))
+func WithRestart(policy RestartPolicy) restartOpt {
+	return restartOpt{policy: policy}
+}
+
+// restartOpt supervises the entrypoint with the restart policy.
+type restartOpt struct {
+	policy RestartPolicy
+}
+
+// applyEntrypoint applies the option to the entrypoint settings.
+func (o restartOpt) applyEntrypoint(s *entrypointSettings) {
+	s.restart = o.policy
+}
+
+// WithStopContext returns a container option that stops restarting entrypoints
+// when the context is done. Closing the container stops restarts as well.
+//
+// Example:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	gontainer.Run(gontainer.WithStopContext(ctx), app.Options()...)
+func WithStopContext(ctx context.Context) Option {
+	return stopContextOpt{ctx: ctx}
+}
+
+// stopContextOpt stops restarting entrypoints when the context is done.
+type stopContextOpt struct {
+	ctx context.Context
+}
+
+// apply applies the stop context option to the given registry.
+func (o stopContextOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.stopCtx = o.ctx
+	return nil
+}
+
+// stopRestarts stops restarting entrypoints.
+func (r *registry) stopRestarts() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.stopped != nil && !r.isStoppedLocked() {
+		close(r.stopped)
+	}
+}
+
+// isStopped returns true when restarting entrypoints is stopped.
+func (r *registry) isStopped() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.isStoppedLocked()
+}
+
+// isStoppedLocked returns true when restarting entrypoints is stopped, the mutex must be held.
+func (r *registry) isStoppedLocked() bool {
+	select {
+	case <-r.stopped:
+		return true
+	case <-r.getStopContextDone():
+		return true
+	default:
+		return false
+	}
+}
+
+// getStopContextDone returns the done channel of the stop context or nil.
+func (r *registry) getStopContextDone() <-chan struct{} {
+	if r.stopCtx == nil {
+		return nil
+	}
+	return r.stopCtx.Done()
+}
+
+// waitRestart waits for the restart delay and returns false when restarts are stopped meanwhile.
+func (r *registry) waitRestart(delay time.Duration) bool {
+	// Prepare stop channels.
+	r.mutex.Lock()
+	stopped, done := r.stopped, r.getStopContextDone()
+	r.mutex.Unlock()
+
+	// Wait for the delay or the stop.
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-stopped:
+		return false
+	case <-done:
+		return false
+	}
+}
+
+// shouldRestart returns true when the entrypoint is restarted after the attempt returned the error.
+func (p RestartPolicy) shouldRestart(attempt int, err error) bool {
+	// Check the attempts limit.
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return false
+	}
+
+	// Check the restart mode.
+	switch p.Mode {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return err != nil
+	default:
+		return false
+	}
+}
+
+// getBackoff returns the delay before the restart following the attempt.
+func (p RestartPolicy) getBackoff(attempt int) time.Duration {
+	// Prepare default delays.
+	backoff, maxBackoff := p.Backoff, p.MaxBackoff
+	if backoff <= 0 {
+		backoff = defaultRestartBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRestartMaxBackoff
+	}
+
+	// Double the delay for every previous restart.
+	for index := 1; index < attempt && backoff < maxBackoff; index++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
@@ -0,0 +1,203 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRestartOnFailure tests entrypoints recovering after restarts.
+func TestRestartOnFailure(t *testing.T) {
+	spawned, attempts := 0, 0
+	var events []Event
+	equal(t, Run(
+		WithEventHandler(func(event Event) {
+			if event.Kind == EventEntrypointFailed || event.Kind == EventEntrypointRestarting {
+				events = append(events, event)
+			}
+		}),
+		NewFactory(func() *testFmtMid { spawned++; return &testFmtMid{} }),
+		NewEntrypoint(func(*testFmtMid) error {
+			attempts++
+			if attempts < 3 {
+				return errors.New("transient")
+			}
+			return nil
+		}, WithRestart(RestartPolicy{Mode: RestartOnFailure, Backoff: time.Millisecond})),
+	), nil)
+	equal(t, spawned, 1)
+	equal(t, attempts, 3)
+	equal(t, len(events), 4)
+	equal(t, events[0].Kind, EventEntrypointFailed)
+	equal(t, events[0].Attempt, 1)
+	equal(t, events[1].Kind, EventEntrypointRestarting)
+	equal(t, events[1].Delay, time.Millisecond)
+	equal(t, events[3].Kind, EventEntrypointRestarting)
+	equal(t, events[3].Attempt, 2)
+	equal(t, events[3].Delay, 2*time.Millisecond)
+	equal(t, events[3].Err.Error(), "transient")
+}
+
+// TestRestartMaxAttempts tests the error of the last attempt is reported.
+func TestRestartMaxAttempts(t *testing.T) {
+	attempts := 0
+	err := Run(
+		NewEntrypoint(func() error {
+			attempts++
+			return errors.New("permanent")
+		}, WithRestart(RestartPolicy{Mode: RestartOnFailure, MaxAttempts: 3, Backoff: time.Millisecond})),
+	)
+	equal(t, attempts, 3)
+	equal(t, errors.Is(err, ErrEntrypointReturnedError), true)
+	equal(t, normalizeSourceLines(err.Error()), ""+
+		"permanent\n\n"+
+		"Traceback:\n"+
+		"  Entrypoint")
+}
+
+// TestRestartAlways tests entrypoints restarted after succeeding.
+func TestRestartAlways(t *testing.T) {
+	attempts := 0
+	equal(t, Run(
+		NewEntrypoint(func() {
+			attempts++
+		}, WithRestart(RestartPolicy{Mode: RestartAlways, MaxAttempts: 3, Backoff: time.Millisecond})),
+	), nil)
+	equal(t, attempts, 3)
+}
+
+// TestRestartStopContext tests restarts end when the stop context is done.
+func TestRestartStopContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	done := make(chan error)
+	go func() {
+		done <- Run(
+			WithStopContext(ctx),
+			NewEntrypoint(func() error {
+				attempts++
+				return errors.New("failed")
+			}, WithRestart(RestartPolicy{Mode: RestartAlways, Backoff: time.Hour})),
+		)
+	}()
+
+	// The backoff wait is interrupted and the last error is reported.
+	cancel()
+	err := <-done
+	equal(t, errors.Is(err, ErrEntrypointReturnedError), true)
+	equal(t, attempts, 1)
+
+	// Clean returns are not restarted once stopped.
+	attempts = 0
+	equal(t, Run(
+		WithStopContext(ctx),
+		NewEntrypoint(func() {
+			attempts++
+		}, WithRestart(RestartPolicy{Mode: RestartAlways, Backoff: time.Millisecond})),
+	), nil)
+	equal(t, attempts, 1)
+}
+
+// TestRestartClose tests restarts end when the container is closed.
+func TestRestartClose(t *testing.T) {
+	started := make(chan struct{})
+	container, err := New(
+		NewEntrypoint(func() {
+			select {
+			case <-started:
+			default:
+				close(started)
+			}
+		}, WithRestart(RestartPolicy{Mode: RestartAlways, Backoff: time.Hour})),
+	)
+	equal(t, err, nil)
+	done := make(chan error)
+	go func() { done <- container.Start() }()
+
+	// Closing the container interrupts the backoff wait.
+	<-started
+	equal(t, container.Close(), nil)
+	equal(t, <-done, nil)
+}
+
+// TestRestartCloseRunning tests dependencies stay alive until the running attempt returns.
+func TestRestartCloseRunning(t *testing.T) {
+	var closed, usedAfterClose atomic.Bool
+	started, release := make(chan struct{}), make(chan struct{})
+	container, err := New(
+		NewFactory(func() (*testFmtLeaf, func() error) {
+			return &testFmtLeaf{}, func() error { closed.Store(true); return nil }
+		}),
+		NewEntrypoint(func(*testFmtLeaf) {
+			started <- struct{}{}
+			<-release
+			usedAfterClose.Store(closed.Load())
+		}, WithRestart(RestartPolicy{Mode: RestartAlways, Backoff: time.Millisecond})),
+	)
+	equal(t, err, nil)
+	done := make(chan error)
+	go func() { done <- container.Start() }()
+
+	// Close the container while the entrypoint is running.
+	<-started
+	closeDone := make(chan error)
+	go func() { closeDone <- container.Close() }()
+	select {
+	case <-closeDone:
+		t.Fatal("container closed while the entrypoint is running")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	// The attempt is not restarted and services are closed after it returns.
+	close(release)
+	equal(t, <-done, nil)
+	equal(t, <-closeDone, nil)
+	equal(t, closed.Load(), true)
+	equal(t, usedAfterClose.Load(), false)
+}
+
+// TestRestartNever tests entrypoints are not restarted by default.
+func TestRestartNever(t *testing.T) {
+	attempts := 0
+	var events []Event
+	err := Run(
+		WithEventHandler(func(event Event) { events = append(events, event) }),
+		NewEntrypoint(func() error {
+			attempts++
+			return errors.New("failed")
+		}),
+	)
+	equal(t, errors.Is(err, ErrEntrypointReturnedError), true)
+	equal(t, attempts, 1)
+	equal(t, len(events), 1)
+	equal(t, events[0].Kind, EventEntrypointFailed)
+}
+
+// TestRestartBackoff tests the exponential backoff of restarts.
+func TestRestartBackoff(t *testing.T) {
+	policy := RestartPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	equal(t, policy.getBackoff(1), time.Second)
+	equal(t, policy.getBackoff(2), 2*time.Second)
+	equal(t, policy.getBackoff(3), 4*time.Second)
+	equal(t, policy.getBackoff(4), 5*time.Second)
+	equal(t, RestartPolicy{}.getBackoff(1), defaultRestartBackoff)
+}
@@ -0,0 +1,70 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"reflect"
+)
+
+// Tagged defines a dependency on zero or more services of the same type
+// whose factories are annotated with a value of type A.
+//
+// This generic wrapper works like Multiple, but only collects services from
+// factories carrying an annotation assignable to type A (see WithAnnotation).
+// To select services by an annotation value, declare a distinct type per value.
+//
+// Example:
+//
+//	type AdminTag struct{}
+//
+//	gontainer.NewFactory(newAuditMiddleware, gontainer.WithAnnotation(AdminTag{}))
+//
+//	func MyFactory(middlewares gontainer.Tagged[Middleware, AdminTag]) {
+//	    for _, m := range middlewares {
+//	        ...
+//	    }
+//	}
+type Tagged[T any, A any] []T
+
+// getTagType returns the annotation type of the tagged box.
+func (Tagged[T, A]) getTagType() reflect.Type {
+	return reflect.TypeOf((*A)(nil)).Elem()
+}
+
+// isTaggedType checks and returns tagged box element and annotation types.
+func isTaggedType(typ reflect.Type) (reflect.Type, reflect.Type, bool) {
+	// Check if the type is a slice.
+	if typ.Kind() != reflect.Slice {
+		return nil, nil, false
+	}
+
+	// Check if the type is a Tagged type.
+	box, ok := reflect.Zero(typ).Interface().(interface{ getTagType() reflect.Type })
+	if !ok {
+		return nil, nil, false
+	}
+
+	// Return the element type of the slice and the annotation type.
+	return typ.Elem(), box.getTagType(), true
+}
+
+// newTaggedValue packs tagged values to the slice.
+func newTaggedValue(typ reflect.Type, values []reflect.Value) reflect.Value {
+	box := reflect.New(typ).Elem()
+	return reflect.Append(box, values...)
+}
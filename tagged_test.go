@@ -0,0 +1,128 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestIsTaggedType tests checking of argument to be tagged.
+func TestIsTaggedType(t *testing.T) {
+	type tag struct{}
+
+	var t1 any
+	var t2 []int
+	var t3 Multiple[int]
+	var t4 Tagged[int, tag]
+
+	typ := reflect.TypeOf(&t1).Elem()
+	rtyp, ttyp, ok := isTaggedType(typ)
+	equal(t, rtyp, nil)
+	equal(t, ttyp, nil)
+	equal(t, ok, false)
+
+	typ = reflect.TypeOf(&t2).Elem()
+	rtyp, ttyp, ok = isTaggedType(typ)
+	equal(t, rtyp, nil)
+	equal(t, ttyp, nil)
+	equal(t, ok, false)
+
+	typ = reflect.TypeOf(&t3).Elem()
+	rtyp, ttyp, ok = isTaggedType(typ)
+	equal(t, rtyp, nil)
+	equal(t, ttyp, nil)
+	equal(t, ok, false)
+
+	typ = reflect.TypeOf(&t4).Elem()
+	rtyp, ttyp, ok = isTaggedType(typ)
+	equal(t, rtyp, reflect.TypeOf((*int)(nil)).Elem())
+	equal(t, ttyp, reflect.TypeOf((*tag)(nil)).Elem())
+	equal(t, ok, true)
+}
+
+// TestNewTaggedValue tests creation of tagged value.
+func TestNewTaggedValue(t *testing.T) {
+	type tag struct{}
+
+	// When tagged not found.
+	box := Tagged[string, tag]{}
+	value := newTaggedValue(reflect.TypeOf(box), nil)
+	equal(t, value.Interface().(Tagged[string, tag]), Tagged[string, tag](nil))
+
+	// When tagged found.
+	data := []reflect.Value{reflect.ValueOf("result1"), reflect.ValueOf("result2")}
+	value = newTaggedValue(reflect.TypeOf(box), data)
+	equal(t, value.Interface().(Tagged[string, tag]), Tagged[string, tag]{"result1", "result2"})
+}
+
+// TestTaggedDependencies tests resolution of tagged dependencies.
+func TestTaggedDependencies(t *testing.T) {
+	type adminTag struct{}
+	type publicTag struct{}
+	type unusedTag struct{}
+
+	svc1 := &testService1{}
+	svc2 := &testService2{}
+	svc3 := &testService3{}
+
+	var admin Tagged[interface{ Do1() }, adminTag]
+	var public Tagged[interface{ Do1() }, publicTag]
+	var anyTag Tagged[interface{ Do1() }, any]
+	var none Tagged[interface{ Do1() }, unusedTag]
+
+	equal(t, Run(
+		NewService(svc1, WithAnnotation(adminTag{})),
+		NewService(svc2, WithAnnotation(publicTag{}), WithAnnotation(adminTag{})),
+		NewService(svc3),
+		NewEntrypoint(func(
+			dep1 Tagged[interface{ Do1() }, adminTag],
+			dep2 Tagged[interface{ Do1() }, publicTag],
+			dep3 Tagged[interface{ Do1() }, any],
+			dep4 Tagged[interface{ Do1() }, unusedTag],
+		) {
+			admin, public, anyTag, none = dep1, dep2, dep3, dep4
+		}),
+	), nil)
+
+	equal(t, admin, Tagged[interface{ Do1() }, adminTag]{svc1, svc2})
+	equal(t, public, Tagged[interface{ Do1() }, publicTag]{svc2})
+	equal(t, anyTag, Tagged[interface{ Do1() }, any]{svc1, svc2})
+	equal(t, none, Tagged[interface{ Do1() }, unusedTag](nil))
+}
+
+// TestTaggedCircularDependency tests cycle detection through tagged dependencies.
+func TestTaggedCircularDependency(t *testing.T) {
+	type tag struct{}
+
+	// Untagged factories are not dependencies of a tagged box.
+	equal(t, Run(
+		NewFactory(func(Tagged[interface{ Do1() }, tag]) *testService1 { return &testService1{} }),
+		NewEntrypoint(func(*testService1) {}),
+	), nil)
+
+	// Tagged factories are dependencies of a tagged box.
+	err := Run(
+		NewFactory(func(Tagged[interface{ Do1() }, tag]) *testService1 {
+			return &testService1{}
+		}, WithAnnotation(tag{})),
+		NewEntrypoint(func(*testService1) {}),
+	)
+	equal(t, errors.Is(err, ErrCircularDependency), true)
+}
@@ -0,0 +1,154 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"slices"
+	"sync"
+)
+
+// WithParallelClose returns a container option that closes spawned factories concurrently.
+//
+// A factory is closed only after all spawned factories depending on it are closed,
+// independent factories are closed at the same time. Dependencies are recorded when
+// services are resolved for a factory, including services resolved dynamically via
+// the Resolver or the Invoker injected into the factory.
+//
+// Close errors are reported in the reverse spawn order, the same as in sequential teardown.
+//
+// Example:
+//
+//	gontainer.Run(gontainer.WithParallelClose(), app.Options()...)
+func WithParallelClose() Option {
+	return parallelCloseOpt{}
+}
+
+// parallelCloseOpt enables the parallel teardown.
+type parallelCloseOpt struct{}
+
+// apply applies the parallel teardown option to the given registry.
+func (o parallelCloseOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.parallelClose = true
+	return nil
+}
+
+// closeFactoriesParallel closes all spawned factories concurrently respecting dependencies.
+func (r *registry) closeFactoriesParallel() error {
+	// Prepare spawned factories and their positions.
+	r.mutex.Lock()
+	sequence := slices.Clone(r.sequence)
+	r.mutex.Unlock()
+	positions := make(map[*factory]int, len(sequence))
+	for position, fact := range sequence {
+		positions[fact] = position
+	}
+
+	// Collect dependencies recorded at resolve time and count dependents of each factory.
+	dependencies := make([][]int, len(sequence))
+	dependents := make([]int, len(sequence))
+	for position, fact := range sequence {
+		for _, depFact := range fact.getSpawnedDependencies() {
+			depPosition, ok := positions[depFact]
+			if !ok || depPosition == position {
+				continue
+			}
+			dependencies[position] = append(dependencies[position], depPosition)
+			dependents[depPosition]++
+		}
+	}
+
+	// Prepare results indexed by the spawn positions.
+	closeErrors := make([]error, len(sequence))
+	closePanics := make([]any, len(sequence))
+
+	// Close a single factory and then its dependencies without other dependents.
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	var closeFactory func(position int)
+	closeFactory = func(position int) {
+		defer wg.Done()
+
+		// Invoke close callback function recovering the panic.
+		func() {
+			defer func() {
+				closePanics[position] = recover()
+			}()
+			closeErrors[position] = r.closeFactory(sequence[position])
+		}()
+
+		// Release dependencies of the factory.
+		var ready []int
+		mutex.Lock()
+		for _, depPosition := range dependencies[position] {
+			dependents[depPosition]--
+			if dependents[depPosition] == 0 {
+				ready = append(ready, depPosition)
+			}
+		}
+		mutex.Unlock()
+
+		// Close released dependencies.
+		for _, depPosition := range ready {
+			wg.Add(1)
+			go closeFactory(depPosition)
+		}
+	}
+
+	// Start closing factories without dependents.
+	var roots []int
+	for position := len(sequence) - 1; position >= 0; position-- {
+		if dependents[position] == 0 {
+			roots = append(roots, position)
+		}
+	}
+	for _, position := range roots {
+		wg.Add(1)
+		go closeFactory(position)
+	}
+
+	// Wait for all factories.
+	wg.Wait()
+
+	// Propagate panics to the calling goroutine.
+	for position := len(sequence) - 1; position >= 0; position-- {
+		if closePanics[position] != nil {
+			panic(closePanics[position])
+		}
+	}
+
+	// Collect errors in the reverse spawn order.
+	var errs errorGroup
+	for position := len(sequence) - 1; position >= 0; position-- {
+		if closeErrors[position] != nil {
+			errs = append(errs, closeErrors[position])
+		}
+	}
+
+	// Return nil if no errors found.
+	if len(errs) == 0 {
+		return nil
+	}
+
+	// Return collected errors.
+	return errs
+}
@@ -0,0 +1,150 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestParallelClose tests concurrent teardown respecting dependencies.
+func TestParallelClose(t *testing.T) {
+	// Independent factories wait for each other to be closing.
+	var closing sync.WaitGroup
+	closing.Add(2)
+	wait := func() bool {
+		closing.Done()
+		done := make(chan struct{})
+		go func() {
+			closing.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return true
+		case <-time.After(5 * time.Second):
+			return false
+		}
+	}
+
+	// Record close events.
+	var mutex sync.Mutex
+	var events []string
+	record := func(event string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, event)
+	}
+
+	// Run container.
+	equal(t, Run(
+		WithParallelClose(),
+		NewFactory(func() (*testFmtLeaf, func() error) {
+			return &testFmtLeaf{}, func() error { record("leaf"); return nil }
+		}),
+		NewFactory(func(*testFmtLeaf) (*testFmtRootA, func() error) {
+			return &testFmtRootA{}, func() error { equal(t, wait(), true); record("root a"); return nil }
+		}),
+		NewFactory(func(Optional[*testFmtLeaf]) (*testFmtRootB, func() error) {
+			return &testFmtRootB{}, func() error { equal(t, wait(), true); record("root b"); return nil }
+		}),
+		NewEntrypoint(func(*testFmtRootA, *testFmtRootB) {}),
+	), nil)
+
+	// The shared dependency is closed last.
+	equal(t, len(events), 3)
+	equal(t, events[2], "leaf")
+}
+
+// TestParallelCloseDynamic tests teardown order of services resolved dynamically by factories.
+func TestParallelCloseDynamic(t *testing.T) {
+	var mutex sync.Mutex
+	var events []string
+	record := func(event string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, event)
+	}
+
+	// Run container.
+	equal(t, Run(
+		WithParallelClose(),
+		NewFactory(func() (*testFmtLeaf, func() error) {
+			return &testFmtLeaf{}, func() error { record("leaf"); return nil }
+		}),
+		NewFactory(func() (*testFmtMid, func() error) {
+			return &testFmtMid{}, func() error { record("mid"); return nil }
+		}),
+		NewFactory(func(resolver *Resolver, invoker *Invoker) (*testFmtRootA, func() error, error) {
+			if _, err := Resolve[*testFmtLeaf](resolver); err != nil {
+				return nil, nil, err
+			}
+			if err := InvokeErr(invoker, func(*testFmtMid) {}); err != nil {
+				return nil, nil, err
+			}
+			return &testFmtRootA{}, func() error {
+				time.Sleep(10 * time.Millisecond)
+				record("root")
+				return nil
+			}, nil
+		}),
+		NewEntrypoint(func(*testFmtRootA) {}),
+	), nil)
+
+	// Dynamically resolved services are closed after the factory.
+	equal(t, len(events), 3)
+	equal(t, events[0], "root")
+}
+
+// TestParallelCloseErrors tests errors of concurrent teardown in the reverse spawn order.
+func TestParallelCloseErrors(t *testing.T) {
+	for index := 0; index < 10; index++ {
+		err := Run(
+			WithParallelClose(),
+			NewFactory(func() (*testFmtLeaf, func() error) {
+				return &testFmtLeaf{}, func() error { return errors.New("leaf boom") }
+			}),
+			NewFactory(func() (*testFmtMid, func() error) {
+				return &testFmtMid{}, func() error { return errors.New("mid boom") }
+			}),
+			NewEntrypoint(func(*testFmtLeaf, *testFmtMid) {}),
+		)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"mid boom\n\n"+
+			"Source:\n"+
+			"  Factory for *gontainer.testFmtMid\n\n"+
+			"leaf boom\n\n"+
+			"Source:\n"+
+			"  Factory for *gontainer.testFmtLeaf")
+	}
+}
+
+// TestParallelClosePanic tests propagation of close callback panics to the calling goroutine.
+func TestParallelClosePanic(t *testing.T) {
+	equal(t, recovers(func() {
+		_ = Run(
+			WithParallelClose(),
+			NewFactory(func() (*testFmtLeaf, func() error) {
+				return &testFmtLeaf{}, func() error { panic("boom") }
+			}),
+			NewEntrypoint(func(*testFmtLeaf) {}),
+		)
+	}), true)
+}
@@ -0,0 +1,125 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"context"
+)
+
+// InvocationKind is the kind of a traced invocation.
+type InvocationKind string
+
+const (
+	// InvocationSpawn is a spawn of a factory, including the resolution of its dependencies.
+	InvocationSpawn InvocationKind = "spawn"
+
+	// InvocationEntrypoint is an entrypoint attempt, including the resolution of its dependencies.
+	InvocationEntrypoint InvocationKind = "entrypoint"
+
+	// InvocationClose is a call of a close callback of a spawned factory.
+	InvocationClose InvocationKind = "close"
+)
+
+// Invocation describes a traced invocation of a factory, an entrypoint or a close callback.
+type Invocation struct {
+	// Kind is the kind of the invocation.
+	Kind InvocationKind
+
+	// Factory is the description of the invoked factory or entrypoint.
+	Factory FactoryInfo
+
+	// Attempt is the invocation attempt of the entrypoint, starting with 1, or zero for factories.
+	Attempt int
+}
+
+// Tracer is the interface for tracing nested invocations of the container.
+type Tracer interface {
+	// Start is called before the invocation with the context of the invocation requesting it.
+	// It returns the context of the invocation passed to nested invocations, and a function
+	// called with the error of the invocation or of its dependencies when it is finished.
+	Start(ctx context.Context, invocation Invocation) (context.Context, func(err error))
+}
+
+// WithTracer returns a container option that traces invocations with the tracer.
+//
+// Factories are traced within the invocation of the factory or the entrypoint requesting
+// them, so nested construction is visible. Entrypoints, close callbacks and factories
+// spawned eagerly are started with the context. A later option replaces the tracer.
+//
+// Example:
+//
+//	gontainer.Run(gontainer.WithTracer(ctx, tracer), app.Options()...)
+func WithTracer(ctx context.Context, tracer Tracer) Option {
+	return tracerOpt{ctx: ctx, tracer: tracer}
+}
+
+// tracerOpt registers the tracer of invocations.
+type tracerOpt struct {
+	ctx    context.Context
+	tracer Tracer
+}
+
+// apply applies the tracer option to the given registry.
+func (o tracerOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.tracer = o.tracer
+	registry.traceCtx = o.ctx
+	return nil
+}
+
+// invokeTraced invokes the factory requested by the requester within a traced invocation.
+func (r *registry) invokeTraced(requester, fact *factory, kind InvocationKind, attempt int) error {
+	// Start the invocation.
+	finish := r.startTrace(requester, fact, kind, attempt)
+
+	// Invoke the factory and finish with the error of its dependencies or of its function.
+	err := r.invokeFactory(fact)
+	if err != nil {
+		finish(err)
+		return err
+	}
+	finish(fact.getOutError())
+	return nil
+}
+
+// startTrace starts the invocation of the factory nested in the invocation of the requester,
+// the nil requester stands for the top level of the container.
+func (r *registry) startTrace(requester, fact *factory, kind InvocationKind, attempt int) func(err error) {
+	// Skip describing the factory without a tracer.
+	if r.tracer == nil {
+		return func(error) {}
+	}
+
+	// Prepare the context of the requesting invocation.
+	ctx := requester.getTraceContext()
+	if ctx == nil {
+		ctx = r.traceCtx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Start the invocation and save its context for nested invocations.
+	ctx, finish := r.tracer.Start(ctx, Invocation{Kind: kind, Factory: newFactoryInfo(fact), Attempt: attempt})
+	fact.setTraceContext(ctx)
+	return finish
+}
@@ -0,0 +1,91 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testTraceKey is the context key of the test invocation name.
+type testTraceKey struct{}
+
+// testTracer records started and finished invocations with their parents.
+type testTracer struct{ traces []string }
+
+func (t *testTracer) Start(ctx context.Context, invocation Invocation) (context.Context, func(err error)) {
+	parent, _ := ctx.Value(testTraceKey{}).(string)
+	name := fmt.Sprintf("%s %v", invocation.Kind, invocation.Factory.Type)
+	t.traces = append(t.traces, fmt.Sprintf("start %s in %s", name, parent))
+	return context.WithValue(ctx, testTraceKey{}, name), func(err error) {
+		t.traces = append(t.traces, fmt.Sprintf("finish %s: %v", name, err))
+	}
+}
+
+// TestTracer tests invocations traced within invocations requesting them.
+func TestTracer(t *testing.T) {
+	tracer := &testTracer{}
+	ctx := context.WithValue(context.Background(), testTraceKey{}, "root")
+	err := Run(
+		WithTracer(ctx, tracer),
+		NewFactory(func() *testFmtLeaf { return &testFmtLeaf{} }),
+		NewFactory(func(*testFmtLeaf) (*testFmtMid, func() error) {
+			return &testFmtMid{}, func() error { return errors.New("close failed") }
+		}),
+		NewEntrypoint(func(*testFmtMid) {}),
+	)
+	equal(t, err != nil, true)
+	equal(t, tracer.traces, []string{
+		"start entrypoint <nil> in root",
+		"start spawn *gontainer.testFmtMid in entrypoint <nil>",
+		"start spawn *gontainer.testFmtLeaf in spawn *gontainer.testFmtMid",
+		"finish spawn *gontainer.testFmtLeaf: <nil>",
+		"finish spawn *gontainer.testFmtMid: <nil>",
+		"finish entrypoint <nil>: <nil>",
+		"start close *gontainer.testFmtMid in root",
+		"finish close *gontainer.testFmtMid: close failed",
+		"start close *gontainer.testFmtLeaf in root",
+		"finish close *gontainer.testFmtLeaf: <nil>",
+	})
+}
+
+// TestTracerDependencyError tests invocations finished with errors of dependencies.
+func TestTracerDependencyError(t *testing.T) {
+	tracer := &testTracer{}
+	var events []Event
+	err := Run(
+		WithTracer(context.Background(), tracer),
+		WithEventHandler(func(event Event) { events = append(events, event) }),
+		NewFactory(func() (*testFmtLeaf, error) { return nil, errors.New("failed") }),
+		NewFactory(func(*testFmtLeaf) *testFmtMid { return &testFmtMid{} }),
+		NewEntrypoint(func(*testFmtMid) {}),
+	)
+	equal(t, errors.Is(err, ErrFactoryReturnedError), true)
+	equal(t, len(tracer.traces), 6)
+	equal(t, tracer.traces[3], "finish spawn *gontainer.testFmtLeaf: failed")
+	equal(t, strings.HasPrefix(tracer.traces[4], "finish spawn *gontainer.testFmtMid: failed\n"), true)
+
+	// Only the called factory function is reported to event handlers.
+	equal(t, len(events), 1)
+	equal(t, events[0].Kind, EventFactorySpawned)
+	equal(t, events[0].Factory.Type, reflect.TypeOf(&testFmtLeaf{}))
+}
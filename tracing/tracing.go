@@ -0,0 +1,114 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package tracing emits OpenTelemetry spans of container factories, entrypoints and close callbacks.
+//
+// A span is started for every factory spawn, nested in the span of the factory or
+// entrypoint requesting the service, so the time of nested construction is visible.
+// The package is a separate module, so the container itself stays dependency-free.
+//
+// Example:
+//
+//	tracer := tracing.New(ctx, otel.GetTracerProvider())
+//	err := gontainer.Run(
+//	    tracer.Option(),
+//	    gontainer.NewFactory(newDatabase),
+//	    gontainer.NewEntrypoint(runServer),
+//	)
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/NVIDIA/gontainer/v2"
+)
+
+// instrumentationName is the name of the tracer of the package.
+const instrumentationName = "github.com/NVIDIA/gontainer/v2/tracing"
+
+// Span attribute keys.
+const (
+	// TypeKey is the output service type of the factory.
+	TypeKey = attribute.Key("gontainer.type")
+
+	// SourceKey is the "<file>:<line>" location of the factory or entrypoint declaration.
+	SourceKey = attribute.Key("gontainer.source")
+
+	// ModuleKey is the path of the enclosing module.
+	ModuleKey = attribute.Key("gontainer.module")
+
+	// AttemptKey is the invocation attempt of the entrypoint.
+	AttemptKey = attribute.Key("gontainer.attempt")
+)
+
+// Tracer emits spans of container invocations.
+type Tracer struct {
+	ctx    context.Context
+	tracer trace.Tracer
+}
+
+// New returns a new tracer starting top-level spans in the context,
+// using the global tracer provider when the provider is nil.
+func New(ctx context.Context, provider trace.TracerProvider) *Tracer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return &Tracer{
+		ctx:    ctx,
+		tracer: provider.Tracer(instrumentationName),
+	}
+}
+
+// Option returns a container option tracing invocations of the container.
+func (t *Tracer) Option() gontainer.Option {
+	return gontainer.WithTracer(t.ctx, t)
+}
+
+// Start starts the span of the invocation nested in the span of the context.
+func (t *Tracer) Start(ctx context.Context, invocation gontainer.Invocation) (context.Context, func(err error)) {
+	// Prepare span attributes.
+	attrs := []attribute.KeyValue{
+		SourceKey.String(invocation.Factory.Source),
+		ModuleKey.String(invocation.Factory.Module),
+	}
+	if invocation.Factory.Type != nil {
+		attrs = append(attrs, TypeKey.String(invocation.Factory.Type.String()))
+	}
+
+	// Prepare the span name.
+	name := "gontainer." + string(invocation.Kind)
+	if invocation.Kind == gontainer.InvocationEntrypoint {
+		attrs = append(attrs, AttemptKey.Int(invocation.Attempt))
+	} else if invocation.Factory.Type != nil {
+		name += " " + invocation.Factory.Type.String()
+	}
+
+	// Start the span and end it with the error of the invocation.
+	ctx, span := t.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
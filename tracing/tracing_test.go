@@ -0,0 +1,122 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/NVIDIA/gontainer/v2"
+)
+
+// Synthetic types of test factories.
+type testConfig struct{}
+type testDatabase struct{}
+
+// TestTracer tests spans of factories nested by requests.
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := New(context.Background(), provider)
+
+	// Run the container.
+	err := gontainer.Run(
+		tracer.Option(),
+		gontainer.NewFactory(func() *testConfig { return &testConfig{} }),
+		gontainer.NewFactory(func(*testConfig) (*testDatabase, func() error) {
+			return &testDatabase{}, func() error { return errors.New("close failed") }
+		}),
+		gontainer.NewEntrypoint(func(*testDatabase) {}),
+	)
+	equal(t, err != nil, true)
+
+	// Collect ended spans by names.
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	var names []string
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+		names = append(names, span.Name())
+	}
+	equal(t, names, []string{
+		"gontainer.spawn *tracing.testConfig",
+		"gontainer.spawn *tracing.testDatabase",
+		"gontainer.entrypoint",
+		"gontainer.close *tracing.testDatabase",
+		"gontainer.close *tracing.testConfig",
+	})
+
+	// Validate the nesting of spans.
+	entrypoint := spans["gontainer.entrypoint"]
+	database := spans["gontainer.spawn *tracing.testDatabase"]
+	config := spans["gontainer.spawn *tracing.testConfig"]
+	equal(t, entrypoint.Parent().IsValid(), false)
+	equal(t, database.Parent().SpanID(), entrypoint.SpanContext().SpanID())
+	equal(t, config.Parent().SpanID(), database.SpanContext().SpanID())
+	equal(t, config.SpanContext().TraceID(), entrypoint.SpanContext().TraceID())
+
+	// Validate span attributes.
+	attrs := map[string]string{}
+	for _, attr := range database.Attributes() {
+		attrs[string(attr.Key)] = attr.Value.Emit()
+	}
+	equal(t, attrs[string(TypeKey)], "*tracing.testDatabase")
+	equal(t, strings.Contains(attrs[string(SourceKey)], "tracing_test.go:"), true)
+	equal(t, attrs[string(ModuleKey)], "")
+
+	// Validate the status of the failed close callback.
+	closeSpan := spans["gontainer.close *tracing.testDatabase"]
+	equal(t, closeSpan.Status().Code, codes.Error)
+	equal(t, closeSpan.Status().Description, "close failed")
+	equal(t, spans["gontainer.close *tracing.testConfig"].Status().Code, codes.Unset)
+}
+
+// TestTracerParentContext tests top-level spans nested in the tracer context.
+func TestTracerParentContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, startup := provider.Tracer("test").Start(context.Background(), "startup")
+
+	// Run the container within the startup span.
+	equal(t, gontainer.Run(
+		New(ctx, provider).Option(),
+		gontainer.NewEntrypoint(func() error { return errors.New("failed") }),
+	) != nil, true)
+	startup.End()
+
+	// Validate the entrypoint span.
+	spans := recorder.Ended()
+	equal(t, len(spans), 2)
+	equal(t, spans[0].Name(), "gontainer.entrypoint")
+	equal(t, spans[0].Parent().SpanID(), startup.SpanContext().SpanID())
+	equal(t, spans[0].Status().Code, codes.Error)
+}
+
+// equal fails the test when values are not deeply equal.
+func equal(t *testing.T, a, b any) {
+	t.Helper()
+	if !reflect.DeepEqual(a, b) {
+		t.Fatalf("equal failed: '%v' != '%v'", a, b)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"slices"
+)
+
+// WithUnusedCheck returns a container option that reports unused factories as validation errors.
+//
+// A factory is unused when it is not eager and its service is not reachable from any
+// entrypoint or eager factory through regular, Optional, Multiple or Tagged dependencies.
+// Services resolved dynamically via the Resolver or the Invoker are not tracked,
+// so such factories are reported too.
+//
+// Example:
+//
+//	err := gontainer.Validate(gontainer.WithUnusedCheck(), app.Options()...)
+//	if errors.Is(err, gontainer.ErrFactoryUnused) { ... }
+func WithUnusedCheck() Option {
+	return unusedCheckOpt{}
+}
+
+// unusedCheckOpt enables the unused factories validation.
+type unusedCheckOpt struct{}
+
+// apply applies the unused check option to the given registry.
+func (o unusedCheckOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.unusedCheck = true
+	return nil
+}
+
+// WithUnspawnedReport returns a container option that reports factories never spawned.
+//
+// The report function is called after all entrypoints have returned and before
+// the factories are closed, with descriptions of registered factories that were
+// not spawned during the run.
+//
+// Example:
+//
+//	gontainer.WithUnspawnedReport(func(infos []gontainer.FactoryInfo) {
+//	    for _, info := range infos {
+//	        log.Printf("factory never spawned: %s at %s", info.Name, info.Source)
+//	    }
+//	})
+func WithUnspawnedReport(report func(unspawned []FactoryInfo)) Option {
+	return unspawnedReportOpt{report: report}
+}
+
+// unspawnedReportOpt registers the unspawned factories report function.
+type unspawnedReportOpt struct {
+	report func(unspawned []FactoryInfo)
+}
+
+// apply applies the unspawned report option to the given registry.
+func (o unspawnedReportOpt) apply(registry *registry) error {
+	if !registry.condition.isSatisfied() {
+		return nil
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.unspawnedReports = append(registry.unspawnedReports, o.report)
+	return nil
+}
+
+// findUnusedFactories returns factories not reachable from any entrypoint or eager factory
+// in registration order.
+func (r *registry) findUnusedFactories() []*factory {
+	// Walk through the dependency graph starting from entrypoints and eager factories.
+	reachable := map[*factory]bool{}
+	queue := slices.Clone(r.entrypoints)
+	for _, fact := range r.factories {
+		if fact.eager {
+			reachable[fact] = true
+			queue = append(queue, fact)
+		}
+	}
+	for len(queue) > 0 {
+		fact := queue[0]
+		queue = queue[1:]
+
+		for _, inType := range fact.inTypes {
+			for _, depFact := range r.findDependencies(inType, fact.module) {
+				if !reachable[depFact] {
+					reachable[depFact] = true
+					queue = append(queue, depFact)
+				}
+			}
+		}
+	}
+
+	// Collect unreachable factories except built-in services.
+	var results []*factory
+	for _, fact := range r.factories {
+		if !fact.builtin && !reachable[fact] {
+			results = append(results, fact)
+		}
+	}
+
+	// Return unused factories.
+	return results
+}
+
+// findUnspawnedFactories returns factories not spawned yet in registration order.
+func (r *registry) findUnspawnedFactories() []*factory {
+	var results []*factory
+	for _, fact := range r.factories {
+		if !fact.builtin && !fact.getIsSpawned() {
+			results = append(results, fact)
+		}
+	}
+	return results
+}
+
+// reportUnspawnedFactories calls registered report functions with factories not spawned yet.
+func (r *registry) reportUnspawnedFactories() {
+	// Skip collecting descriptions without report functions.
+	if len(r.unspawnedReports) == 0 {
+		return
+	}
+
+	// Call all report functions.
+	infos := newFactoryInfos(r.findUnspawnedFactories())
+	for _, report := range r.unspawnedReports {
+		report(slices.Clone(infos))
+	}
+}
@@ -0,0 +1,110 @@
+/*
+ * SPDX-FileCopyrightText: Copyright (c) 2003 NVIDIA CORPORATION & AFFILIATES. All rights reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gontainer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestInspectorUnused tests reporting of factories not reachable from entrypoints.
+func TestInspectorUnused(t *testing.T) {
+	type tagA struct{}
+
+	var unused []FactoryInfo
+	equal(t, Run(
+		NewService(1),
+		NewService(int64(2)),
+		NewService(int32(3), WithAnnotation(tagA{})),
+		NewFactory(func(i int, _ Optional[int64]) string { return "" }),
+		NewService(true),
+		NewService(1.5),
+		NewEntrypoint(func(inspector *Inspector, _ string, _ Multiple[fmt.Stringer], _ Tagged[int32, tagA]) {
+			unused = inspector.Unused()
+		}),
+	), nil)
+
+	equal(t, len(unused), 2)
+	equal(t, unused[0].Name, "Service[bool]")
+	equal(t, unused[1].Name, "Service[float64]")
+}
+
+// TestUnusedCheck tests validation of unused factories.
+func TestUnusedCheck(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		equal(t, Validate(
+			NewService(&testFmtLeaf{}),
+			NewEntrypoint(func() {}),
+		), nil)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		err := Validate(
+			WithUnusedCheck(),
+			NewService(&testFmtLeaf{}),
+			NewEntrypoint(func() {}),
+		)
+		equal(t, errors.Is(err, ErrFactoryUnused), true)
+		equal(t, normalizeSourceLines(err.Error()), ""+
+			"factory unused: *gontainer.testFmtLeaf\n\n"+
+			"Traceback:\n"+
+			"  Factory for *gontainer.testFmtLeaf")
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		invoked := false
+		err := Run(
+			WithUnusedCheck(),
+			NewService(&testFmtLeaf{}),
+			NewEntrypoint(func() { invoked = true }),
+		)
+		equal(t, errors.Is(err, ErrFactoryUnused), true)
+		equal(t, invoked, false)
+	})
+
+	t.Run("Used", func(t *testing.T) {
+		equal(t, Run(
+			WithUnusedCheck(),
+			NewService(&testFmtLeaf{}),
+			NewEntrypoint(func(Optional[*testFmtLeaf], *Resolver) {}),
+		), nil)
+	})
+}
+
+// TestUnspawnedReport tests reporting of factories never spawned.
+func TestUnspawnedReport(t *testing.T) {
+	var reports [][]FactoryInfo
+	report := func(unspawned []FactoryInfo) {
+		reports = append(reports, unspawned)
+	}
+
+	equal(t, Run(
+		WithUnspawnedReport(report),
+		NewService("used"),
+		NewService(1),
+		NewEntrypoint(func(string) {}),
+		WithUnspawnedReport(report),
+	), nil)
+
+	equal(t, len(reports), 2)
+	equal(t, len(reports[0]), 1)
+	equal(t, reports[0][0].Name, "Service[int]")
+	equal(t, reports[0][0].Spawned, false)
+	equal(t, reports[1], reports[0])
+}